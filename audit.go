@@ -0,0 +1,199 @@
+package hoard
+
+import (
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// slotChange is a struct that describes a change of a slot applied to a [Hoarder].
+// This struct is used internally and should not be used directly.
+type slotChange struct {
+
+	// inventoryName is the name of the [Inventory] holding the slot.
+	inventoryName string
+
+	// name is the name of the slot.
+	name string
+
+	// previous is the record describing the slot before the change.
+	previous record
+
+	// current is the record describing the slot after the change.
+	current record
+}
+
+// AuditKind is a type that describes what happened to a slot of an [Inventory].
+type AuditKind int
+
+const (
+	// AuditRegistered means a thing was hoarded into an empty slot.
+	AuditRegistered AuditKind = iota + 1
+
+	// AuditOverridden means a thing was hoarded into a slot already holding another thing.
+	AuditOverridden
+
+	// AuditDiscarded means a thing was discarded from its slot.
+	AuditDiscarded
+)
+
+// String is a method that returns the name of the [AuditKind].
+func (k AuditKind) String() string {
+	switch k {
+	case AuditRegistered:
+		return "registered"
+	case AuditOverridden:
+		return "overridden"
+	case AuditDiscarded:
+		return "discarded"
+	}
+
+	return "unknown"
+}
+
+// AuditEvent is a struct that describes a single change of a slot of an [Inventory] held by a [Hoarder].
+// The struct is delivered to the [AuditSink] set with the [HoardOptions.WithAuditSink] method.
+type AuditEvent struct {
+
+	// Kind describes what happened to the slot.
+	Kind AuditKind
+
+	// Inventory is the name of the [Inventory] holding the slot, as given to the [UseInventory] function, or "default" for the default one.
+	Inventory string
+
+	// Type is the type of the thing held by the slot after the change, or before the change if it was discarded.
+	Type string
+
+	// Name is the custom [Item] name of the slot, as given to the [RememberAs] function, or an empty string if none.
+	Name string
+
+	// Version is the version of the slot after the change.
+	Version uint64
+
+	// Caller is the file and line, formatted as "file:line", of the code that changed the slot.
+	Caller string
+
+	// PreviousCaller is the file and line, formatted as "file:line", of the code that previously changed the slot,
+	// or an empty string if the slot was empty.
+	PreviousCaller string
+
+	// At is the time the slot was changed.
+	At time.Time
+}
+
+// String is a method that returns a human-readable description of the [AuditEvent].
+// Example output:
+//
+//	*db.Client in inventory default overridden by payments/init.go:42, previously from main.go:17
+func (e AuditEvent) String() string {
+	b := strings.Builder{}
+
+	b.WriteString(e.Type)
+
+	if e.Name != "" {
+		b.WriteString(" named " + strconv.Quote(e.Name))
+	}
+
+	b.WriteString(" in inventory " + e.Inventory + " " + e.Kind.String())
+
+	if e.Caller != "" {
+		b.WriteString(" by " + shortCaller(e.Caller))
+	}
+
+	if e.PreviousCaller != "" {
+		b.WriteString(", previously from " + shortCaller(e.PreviousCaller))
+	}
+
+	return b.String()
+}
+
+// AuditSink is an interface that receives an [AuditEvent] for every change of a [Hoarder].
+// Set it with the [HoardOptions.WithAuditSink] method.
+// The sink is called synchronously after the change is visible and without holding any lock of the [Hoarder],
+// hence it must be safe for concurrent use and should return quickly.
+type AuditSink interface {
+
+	// Audit is called for every change of the [Hoarder].
+	Audit(event AuditEvent)
+}
+
+// AuditFunc is a function type that implements the [AuditSink] interface.
+// Example usage:
+//
+//	Hoard(HoardOptions{}.WithAuditSink(AuditFunc(func(e AuditEvent) { log.Println(e) })))
+type AuditFunc func(event AuditEvent)
+
+// Audit is a method that calls the function itself.
+func (f AuditFunc) Audit(event AuditEvent) {
+	f(event)
+}
+
+// newAuditEvent is a function that returns a new [AuditEvent] describing the given change.
+func newAuditEvent(c slotChange) AuditEvent {
+	e := AuditEvent{
+		Kind:      AuditOverridden,
+		Inventory: getOriginalInventoryName(c.inventoryName),
+		Version:   c.current.version,
+		Caller:    c.current.caller(),
+		At:        c.current.at,
+	}
+
+	thing := c.current.item
+
+	switch {
+	case c.current.item == nil:
+		e.Kind = AuditDiscarded
+		thing = c.previous.item
+	case c.previous.item == nil:
+		e.Kind = AuditRegistered
+	}
+
+	if c.previous.item != nil {
+		e.PreviousCaller = c.previous.caller()
+	}
+
+	if thing != nil {
+		e.Type, e.Name = getItemTypeAndName(c.name, reflect.TypeOf(thing.use()))
+	}
+
+	return e
+}
+
+// getOriginalInventoryName is a function that returns the inventory name as given to the [UseInventory] function,
+// or "default" for the default inventory.
+func getOriginalInventoryName(inventoryName string) string {
+	if inventoryName == defaultInventoryName {
+		return defaultInventoryName
+	}
+
+	return strings.TrimSuffix(inventoryName, defaultInventoryName)
+}
+
+// getItemTypeAndName is a function that returns the type and the custom [Item] name of the slot with the given name holding a thing of the given type.
+// The type is "<nil>" if the slot holds a nil thing.
+func getItemTypeAndName(thingName string, typeOfThing reflect.Type) (string, string) {
+	aliasName := getAliasThingName(thingName)
+
+	// a slot named after neither a type nor a type and an alias is only named after its alias
+	if aliasName == "" && thingName != getThingName(typeOfThing) {
+		aliasName = thingName
+	}
+
+	if typeOfThing == nil {
+		return "<nil>", aliasName
+	}
+
+	return typeOfThing.String(), aliasName
+}
+
+// shortCaller is a function that returns the given caller with its file path shortened to the file and its parent directory.
+func shortCaller(caller string) string {
+	dir, file := filepath.Split(caller)
+
+	return filepath.Join(filepath.Base(dir), file)
+}
+
+// compile-time check whether the AuditFunc implements AuditSink
+var _ AuditSink = AuditFunc(nil)
@@ -0,0 +1,236 @@
+package hoard
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// auditRecorder is an AuditSink collecting every received AuditEvent.
+type auditRecorder struct {
+	events []AuditEvent
+	mu     sync.Mutex
+}
+
+func (r *auditRecorder) Audit(event AuditEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, event)
+}
+
+func (r *auditRecorder) got() []AuditEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]AuditEvent{}, r.events...)
+}
+
+func (s *suiteTest) TestAuditSink() {
+	tests := []struct {
+		name          string
+		givenChanges  func(h Hoarder, opt HoardOptions)
+		wantKinds     []AuditKind
+		wantInventory []string
+		wantNames     []string
+	}{
+		{
+			name: "should report a registration",
+			givenChanges: func(h Hoarder, opt HoardOptions) {
+				Hoard(opt, "test")
+			},
+			wantKinds:     []AuditKind{AuditRegistered},
+			wantInventory: []string{"default"},
+			wantNames:     []string{""},
+		},
+		{
+			name: "should report an override",
+			givenChanges: func(h Hoarder, opt HoardOptions) {
+				Hoard(opt, "test")
+				Hoard(opt, "test2")
+			},
+			wantKinds:     []AuditKind{AuditRegistered, AuditOverridden},
+			wantInventory: []string{"default", "default"},
+			wantNames:     []string{"", ""},
+		},
+		{
+			name: "should report a discard",
+			givenChanges: func(h Hoarder, opt HoardOptions) {
+				Hoard(opt, "test")
+				Discard[string](nil, h)
+			},
+			wantKinds:     []AuditKind{AuditRegistered, AuditDiscarded},
+			wantInventory: []string{"default", "default"},
+			wantNames:     []string{"", ""},
+		},
+		{
+			name: "should report the custom inventory and item name",
+			givenChanges: func(h Hoarder, opt HoardOptions) {
+				Hoard(opt, UseInventory("test").Put(RememberAs("test", "name")))
+			},
+			wantKinds:     []AuditKind{AuditRegistered, AuditRegistered, AuditRegistered, AuditRegistered, AuditRegistered, AuditRegistered},
+			wantInventory: []string{"default", "default", "default", "test", "test", "test"},
+			wantNames:     []string{"", "name", "name", "", "name", "name"},
+		},
+		{
+			name: "should report the changes of a successful transaction",
+			givenChanges: func(h Hoarder, opt HoardOptions) {
+				_ = h.Update(func(tx *Tx) error {
+					tx.Put("test")
+
+					return nil
+				})
+			},
+			wantKinds:     []AuditKind{AuditRegistered},
+			wantInventory: []string{"default"},
+			wantNames:     []string{""},
+		},
+		{
+			name: "should not report the changes of a failed transaction",
+			givenChanges: func(h Hoarder, opt HoardOptions) {
+				_ = h.Update(func(tx *Tx) error {
+					tx.Put("test")
+
+					return errors.New("test error")
+				})
+			},
+			wantKinds:     []AuditKind{},
+			wantInventory: []string{},
+			wantNames:     []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			recorder := &auditRecorder{}
+
+			h := factory()
+
+			opt := HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h).WithAuditSink(recorder)
+
+			// set the sink beforehand for the changes not made through the Hoard function
+			Hoard(opt)
+
+			tt.givenChanges(h, opt)
+
+			got := recorder.got()
+
+			gotKinds := []AuditKind{}
+			gotInventory := []string{}
+			gotNames := []string{}
+			for _, e := range got {
+				gotKinds = append(gotKinds, e.Kind)
+				gotInventory = append(gotInventory, e.Inventory)
+				gotNames = append(gotNames, e.Name)
+
+				require.Equal(s.T(), "string", e.Type)
+				require.True(s.T(), strings.Contains(e.Caller, "audit_test.go:"), e.Caller)
+				require.False(s.T(), e.At.IsZero())
+			}
+
+			require.Equal(s.T(), tt.wantKinds, gotKinds)
+			require.ElementsMatch(s.T(), tt.wantInventory, gotInventory)
+			require.ElementsMatch(s.T(), tt.wantNames, gotNames)
+		})
+	}
+}
+
+func (s *suiteTest) TestAuditSink_previousCaller() {
+	recorder := &auditRecorder{}
+
+	h := factory()
+
+	opt := HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h).WithAuditSink(recorder)
+
+	Hoard(opt, "test")
+	Hoard(opt, "test2")
+
+	got := recorder.got()
+
+	require.Len(s.T(), got, 2)
+	require.Empty(s.T(), got[0].PreviousCaller)
+	require.Equal(s.T(), got[0].Caller, got[1].PreviousCaller)
+	require.NotEqual(s.T(), got[0].Caller, got[1].Caller)
+	require.Greater(s.T(), got[1].Version, got[0].Version)
+}
+
+func (s *suiteTest) TestAuditSink_standaloneHoarder() {
+	recorder := &auditRecorder{}
+
+	h := Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithAuditSink(recorder), "test")
+
+	got := recorder.got()
+
+	require.Len(s.T(), got, 1)
+	require.Equal(s.T(), AuditRegistered, got[0].Kind)
+	require.Equal(s.T(), "string", got[0].Type)
+	require.True(s.T(), strings.Contains(got[0].Caller, "audit_test.go:"), got[0].Caller)
+
+	// the returned hoarder keeps reporting to the sink
+	Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), "test2")
+
+	got = recorder.got()
+
+	require.Len(s.T(), got, 2)
+	require.Equal(s.T(), AuditOverridden, got[1].Kind)
+	require.Equal(s.T(), "test2", EquipDefault[string](h))
+}
+
+func (s *suiteTest) TestAuditSink_nilThing() {
+	recorder := &auditRecorder{}
+
+	h := factory()
+
+	require.NotPanics(s.T(), func() {
+		Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), RememberAs(nil, "x"))
+		Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h).WithAuditSink(recorder), RememberAs(nil, "y"))
+	})
+
+	got := recorder.got()
+
+	require.NotEmpty(s.T(), got)
+	for _, e := range got {
+		require.Equal(s.T(), "<nil>", e.Type)
+	}
+}
+
+func (s *suiteTest) TestAuditEvent_String() {
+	tests := []struct {
+		name  string
+		given AuditEvent
+		want  string
+	}{
+		{
+			name: "should describe an override",
+			given: AuditEvent{
+				Kind:           AuditOverridden,
+				Inventory:      "default",
+				Type:           "*db.Client",
+				Caller:         "/src/app/payments/init.go:42",
+				PreviousCaller: "/src/app/main.go:17",
+				At:             time.Now(),
+			},
+			want: "*db.Client in inventory default overridden by payments/init.go:42, previously from app/main.go:17",
+		},
+		{
+			name: "should describe a named registration",
+			given: AuditEvent{
+				Kind:      AuditRegistered,
+				Inventory: "cache",
+				Type:      "string",
+				Name:      "primary",
+				Caller:    "main.go:17",
+			},
+			want: `string named "primary" in inventory cache registered by main.go:17`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			require.Equal(s.T(), tt.want, tt.given.String())
+		})
+	}
+}
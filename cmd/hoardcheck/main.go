@@ -0,0 +1,18 @@
+// Command hoardcheck cross-checks the call sites hoarding things with the call sites equipping them.
+//
+// Usage:
+//
+//	go install github.com/oopchi/hoard/cmd/hoardcheck@latest
+//	go vet -vettool=$(which hoardcheck) ./...
+//
+// Refer to the [hoardcheck] package for the reported problems.
+package main
+
+import (
+	"github.com/oopchi/hoard/hoardcheck"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(hoardcheck.Analyzer)
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/oopchi/hoard/internal/callsite"
+	"golang.org/x/tools/go/packages"
+)
+
+// dynamic is the placeholder of a type, inventory name or item name unknown at compile time.
+const dynamic = "(dynamic)"
+
+// Catalogue is a struct that lists every thing hoarded or equipped by the scanned packages.
+type Catalogue struct {
+
+	// Things holds the things, sorted by type, inventory and name.
+	Things []Thing `json:"things"`
+}
+
+// Thing is a struct that describes a thing hoarded or equipped by the scanned packages.
+type Thing struct {
+
+	// Type is the type of the thing, as printed by the reflect package.
+	Type string `json:"type"`
+
+	// Inventory is the name of the inventory of the thing, "default" for the default inventory.
+	Inventory string `json:"inventory"`
+
+	// Name is the custom item name of the thing, or an empty string if none.
+	Name string `json:"name,omitempty"`
+
+	// Registrations holds the call sites hoarding the thing.
+	// The thing is never registered within the scanned packages if empty.
+	Registrations []Reference `json:"registrations"`
+
+	// Consumers holds the call sites equipping the thing.
+	Consumers []Reference `json:"consumers"`
+}
+
+// Reference is a struct that describes a call site hoarding or equipping a thing.
+type Reference struct {
+
+	// Package is the import path of the package of the call site.
+	Package string `json:"package"`
+
+	// Func is the hoard function or method called, such as "Hoard" or "EquipWithOption".
+	Func string `json:"func"`
+
+	// Position is the call site, formatted as "package/file:line".
+	Position string `json:"position"`
+}
+
+// build is a function that returns the [Catalogue] of the given packages.
+func build(pkgs []*packages.Package) Catalogue {
+	hoarded := make([]callsite.Site, 0)
+	equipped := make([]callsite.Site, 0)
+	roots := make([]*types.Package, 0, len(pkgs))
+
+	for _, pkg := range pkgs {
+		if pkg.Types == nil || pkg.PkgPath == callsite.HoardPath {
+			continue
+		}
+
+		roots = append(roots, pkg.Types)
+
+		c := callsite.NewCollector(pkg.Fset, pkg.Types, pkg.TypesInfo, nil)
+
+		for _, file := range pkg.Syntax {
+			ast.Inspect(file, func(n ast.Node) bool {
+				if call, ok := n.(*ast.CallExpr); ok {
+					c.Visit(call)
+				}
+
+				return true
+			})
+		}
+
+		hoarded = append(hoarded, c.Hoarded...)
+		equipped = append(equipped, c.Equipped...)
+	}
+
+	r := callsite.NewResolver(roots...)
+
+	things := make(map[[3]string]*Thing)
+	thingOf := func(s callsite.Site) *Thing {
+		t := Thing{
+			Type:      s.Type,
+			Inventory: callsite.InventoryName(s.Inventory),
+			Name:      s.Name,
+		}
+
+		if s.DynamicType {
+			t.Type = dynamic
+		}
+
+		if s.DynamicInventory {
+			t.Inventory = dynamic
+		}
+
+		if s.DynamicName {
+			t.Name = dynamic
+		}
+
+		key := [3]string{t.Type, t.Inventory, t.Name}
+		if _, ok := things[key]; !ok {
+			t.Registrations = make([]Reference, 0)
+			t.Consumers = make([]Reference, 0)
+			things[key] = &t
+		}
+
+		return things[key]
+	}
+
+	for _, h := range hoarded {
+		// an inventory inheriting from others registers no thing on its own
+		if h.Inherits {
+			continue
+		}
+
+		t := thingOf(h)
+		t.Registrations = append(t.Registrations, referenceOf(h))
+	}
+
+	for _, e := range equipped {
+		found := false
+
+		for _, h := range hoarded {
+			// a consumer is only listed under the things it is known to equip
+			if h.DynamicType || h.Inherits || !inventoryMatches(e, h) || !r.Matches(e, h) {
+				continue
+			}
+
+			t := thingOf(h)
+			t.Consumers = append(t.Consumers, referenceOf(e))
+			found = true
+		}
+
+		if !found {
+			t := thingOf(e)
+			t.Consumers = append(t.Consumers, referenceOf(e))
+		}
+	}
+
+	c := Catalogue{
+		Things: make([]Thing, 0, len(things)),
+	}
+
+	for _, t := range things {
+		t.Registrations = compactReferences(t.Registrations)
+		t.Consumers = compactReferences(t.Consumers)
+
+		c.Things = append(c.Things, *t)
+	}
+
+	slices.SortFunc(c.Things, func(a, b Thing) int {
+		return cmp.Or(cmp.Compare(a.Type, b.Type), cmp.Compare(a.Inventory, b.Inventory), cmp.Compare(a.Name, b.Name))
+	})
+
+	return c
+}
+
+// inventoryMatches is a function that reports whether the given equipped thing may be found in the inventory of the given hoarded thing.
+// The things of every inventory are also put into the default inventory.
+func inventoryMatches(e, h callsite.Site) bool {
+	return e.Inventory == "" || e.DynamicInventory || h.DynamicInventory || e.Inventory == h.Inventory
+}
+
+// referenceOf is a function that returns the [Reference] of the given call site.
+func referenceOf(s callsite.Site) Reference {
+	return Reference{
+		Package:  s.Package,
+		Func:     s.Func,
+		Position: s.Position,
+	}
+}
+
+// compactReferences is a function that returns the given references sorted by position, without duplicates.
+func compactReferences(refs []Reference) []Reference {
+	slices.SortFunc(refs, func(a, b Reference) int {
+		return cmp.Or(cmp.Compare(a.Position, b.Position), cmp.Compare(a.Func, b.Func))
+	})
+
+	return slices.Compact(refs)
+}
+
+// WriteJSON is a method that writes the [Catalogue] to the given writer as indented JSON.
+func (c Catalogue) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(c)
+}
+
+// WriteMarkdown is a method that writes the [Catalogue] to the given writer as a Markdown table, listing the registering and consuming packages of each thing.
+func (c Catalogue) WriteMarkdown(w io.Writer) error {
+	b := strings.Builder{}
+
+	b.WriteString("# Hoard Catalogue\n\n")
+	b.WriteString("| Type | Inventory | Name | Registered by | Consumed by |\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+
+	for _, t := range c.Things {
+		registeredBy := packagesOf(t.Registrations)
+		if registeredBy == "" {
+			registeredBy = "**never registered**"
+		}
+
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %s |\n",
+			escapeMarkdown(t.Type),
+			escapeMarkdown(t.Inventory),
+			escapeMarkdown(t.Name),
+			registeredBy,
+			packagesOf(t.Consumers),
+		)
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// packagesOf is a function that returns the distinct packages of the given references, comma-separated.
+func packagesOf(refs []Reference) string {
+	pkgs := make([]string, 0, len(refs))
+	for _, r := range refs {
+		pkgs = append(pkgs, escapeMarkdown(r.Package))
+	}
+
+	slices.Sort(pkgs)
+
+	return strings.Join(slices.Compact(pkgs), ", ")
+}
+
+// escapeMarkdown is a function that escapes the given text to be written within a Markdown table cell.
+func escapeMarkdown(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(text)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogue(t *testing.T) {
+	c, err := scan(false, "./testdata/app", "./testdata/orders", "./testdata/payments")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		golden string
+		write  func(b *bytes.Buffer) error
+	}{
+		{
+			name:   "should write the catalogue as markdown",
+			golden: "catalogue.md",
+			write: func(b *bytes.Buffer) error {
+				return c.WriteMarkdown(b)
+			},
+		},
+		{
+			name:   "should write the catalogue as json",
+			golden: "catalogue.json",
+			write: func(b *bytes.Buffer) error {
+				return c.WriteJSON(b)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := os.ReadFile(filepath.Join("testdata", tt.golden))
+			require.NoError(t, err)
+
+			b := bytes.Buffer{}
+			require.NoError(t, tt.write(&b))
+
+			require.Equal(t, string(want), b.String())
+		})
+	}
+}
+
+func TestCatalogue_consumers(t *testing.T) {
+	c, err := scan(false, "./testdata/app", "./testdata/orders", "./testdata/payments")
+	require.NoError(t, err)
+
+	consumers := make(map[string][]string)
+	for _, thing := range c.Things {
+		for _, r := range thing.Consumers {
+			consumers[thing.Type] = append(consumers[thing.Type], r.Func)
+		}
+	}
+
+	// the interface is consumed through the implementation hoarded in its inventory
+	require.Equal(t, []string{"EquipWithOption"}, consumers["payments.Stripe"])
+	require.Equal(t, []string{"EquipDefault", "EquipDefault"}, consumers["*payments.Client"])
+	require.NotContains(t, consumers, "payments.Gateway")
+}
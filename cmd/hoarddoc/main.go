@@ -0,0 +1,94 @@
+// Command hoarddoc writes a catalogue of the things hoarded and equipped by a module.
+//
+// It scans the given packages for every call to the [hoard.Hoard], [hoard.RememberAs] and [hoard.UseInventory] functions and to the equip functions,
+// such as [hoard.EquipDefault] or [hoard.EquipWithOption], and lists, for each thing, which packages register it under which inventory and custom item name,
+// and which packages consume it.
+// A thing consumed but never registered within the scanned packages is highlighted.
+//
+// The things are resolved the same way as the hoardcheck analyzer does, refer to the hoardcheck package for more details.
+// A type, inventory name or item name unknown at compile time is written as "(dynamic)".
+//
+// Usage:
+//
+//	hoarddoc [-format markdown|json] [-o file] [-tests] [packages]
+//
+// The packages default to "./...".
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"golang.org/x/tools/go/packages"
+)
+
+var (
+	format = flag.String("format", "markdown", "output format, either markdown or json")
+	output = flag.String("o", "", "output file, the standard output if empty")
+	tests  = flag.Bool("tests", false, "also scan the test files")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("hoarddoc: ")
+
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: hoarddoc [flags] [packages]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	c, err := scan(*tests, patterns...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	switch *format {
+	case "markdown":
+		err = c.WriteMarkdown(w)
+	case "json":
+		err = c.WriteJSON(w)
+	default:
+		err = fmt.Errorf("unknown format %q, expected markdown or json", *format)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// scan is a function that returns the [Catalogue] of the packages matching the given patterns.
+func scan(tests bool, patterns ...string) (Catalogue, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+		Tests: tests,
+	}, patterns...)
+	if err != nil {
+		return Catalogue{}, err
+	}
+
+	if packages.PrintErrors(pkgs) > 0 {
+		return Catalogue{}, fmt.Errorf("failed to load the packages")
+	}
+
+	return build(pkgs), nil
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"github.com/oopchi/hoard"
+
+	"github.com/oopchi/hoard/cmd/hoarddoc/testdata/orders"
+	"github.com/oopchi/hoard/cmd/hoarddoc/testdata/payments"
+)
+
+type Config struct{}
+
+func main() {
+	payments.Register(nil)
+	orders.Register()
+
+	_ = hoard.EquipDefault[*payments.Client]()
+	_ = hoard.EquipDefault[*Config]()
+
+	orders.Checkout()
+}
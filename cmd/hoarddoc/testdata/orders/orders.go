@@ -0,0 +1,19 @@
+package orders
+
+import (
+	"github.com/oopchi/hoard"
+
+	"github.com/oopchi/hoard/cmd/hoarddoc/testdata/payments"
+)
+
+type Repository struct{}
+
+func Register() {
+	hoard.Hoard(nil, hoard.RememberAs(&Repository{}, "primary"))
+}
+
+func Checkout() {
+	_ = hoard.EquipWithOption[payments.Gateway](hoard.EquipOptions{}.WithCustomInventoryName("payments"))
+	_ = hoard.EquipDefault[*payments.Client]()
+	_ = hoard.EquipWithOption[*Repository](hoard.EquipOptions{}.WithCustomItemName("primary"))
+}
@@ -0,0 +1,24 @@
+package payments
+
+import (
+	"github.com/oopchi/hoard"
+)
+
+type Gateway interface {
+	Charge(amount int) error
+}
+
+type Stripe struct{}
+
+func (Stripe) Charge(amount int) error { return nil }
+
+type Client struct{}
+
+func Register(item hoard.Item) {
+	hoard.Hoard(
+		nil,
+		&Client{},
+		hoard.UseInventory("payments").Put(hoard.RememberAs(Stripe{}, "stripe")),
+		item,
+	)
+}
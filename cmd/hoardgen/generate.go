@@ -0,0 +1,554 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	// hoardPath is the import path of the hoard package.
+	hoardPath = "github.com/oopchi/hoard"
+
+	// directive is the prefix of the comments declaring a thing.
+	directive = "//hoard:item"
+)
+
+// item is a struct that describes a thing declared by a directive.
+type item struct {
+
+	// typ is the type of the thing.
+	typ types.Type
+
+	// inventory is the custom inventory name of the thing.
+	inventory string
+
+	// name is the custom item name of the thing.
+	name string
+
+	// accessor is the name of the generated accessor.
+	accessor string
+
+	// variable is the name of the variable holding the thing, or an empty string if the directive is on a type.
+	variable string
+
+	// param is the name of the parameter of the registration function holding the thing, if the directive is on a type.
+	param string
+
+	// pos is the position of the directive.
+	pos token.Position
+}
+
+// key is a method that returns the name of the generated [hoard.Key] variable of the thing.
+func (it item) key() string {
+	return "key" + strings.TrimPrefix(it.accessor, "Equip")
+}
+
+// generate is a function that returns the formatted source of the accessors of the things declared in the package in the given directory.
+// The given output file is ignored when loading the package, since it is about to be replaced.
+func generate(dir, output, register string) ([]byte, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+		Dir:  dir,
+	}, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected one package in %s, found %d", dir, len(pkgs))
+	}
+
+	pkg := pkgs[0]
+
+	errs := make([]error, 0)
+	for _, e := range pkg.Errors {
+		// the previously generated file may be stale
+		if filepath.Base(strings.Split(e.Pos, ":")[0]) == output {
+			continue
+		}
+
+		errs = append(errs, e)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	items, err := collect(pkg, output)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no %s directive found in package %s", directive, pkg.PkgPath)
+	}
+
+	return render(pkg.Types, items, register)
+}
+
+// collect is a function that returns the things declared by the directives of the given package, skipping the given output file.
+func collect(pkg *packages.Package, output string) ([]item, error) {
+	items := make([]item, 0)
+	errs := make([]error, 0)
+
+	for _, file := range pkg.Syntax {
+		if filepath.Base(pkg.Fset.File(file.Pos()).Name()) == output {
+			continue
+		}
+
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || (decl.Tok != token.TYPE && decl.Tok != token.VAR) {
+				continue
+			}
+
+			for _, spec := range decl.Specs {
+				doc := decl.Doc
+				if decl.Lparen.IsValid() {
+					doc = nil
+				}
+
+				var (
+					ident *ast.Ident
+					names []*ast.Ident
+				)
+
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					doc = firstDoc(spec.Doc, doc)
+					ident, names = spec.Name, []*ast.Ident{spec.Name}
+				case *ast.ValueSpec:
+					doc = firstDoc(spec.Doc, doc)
+					ident, names = spec.Names[0], spec.Names
+				}
+
+				for _, c := range directives(doc) {
+					pos := pkg.Fset.Position(c.Pos())
+
+					if len(names) > 1 {
+						errs = append(errs, fmt.Errorf("%s: %s must declare a single variable", pos, directive))
+						continue
+					}
+
+					it, err := parse(c.Text, pos)
+					if err != nil {
+						errs = append(errs, err)
+						continue
+					}
+
+					switch obj := pkg.TypesInfo.Defs[ident].(type) {
+					case *types.TypeName:
+						if named, ok := obj.Type().(*types.Named); ok && named.TypeParams().Len() > 0 {
+							errs = append(errs, fmt.Errorf("%s: %s cannot declare the generic type %s", pos, directive, obj.Name()))
+							continue
+						}
+
+						it.typ = obj.Type()
+					case *types.Var:
+						it.typ = obj.Type()
+						it.variable = obj.Name()
+					default:
+						continue
+					}
+
+					if it.accessor == "" {
+						it.accessor = "Equip" + accessorName(it.name, typeName(it.typ))
+					}
+
+					if !token.IsIdentifier(it.accessor) {
+						errs = append(errs, fmt.Errorf("%s: invalid accessor name %q, use the accessor key to specify one", pos, it.accessor))
+						continue
+					}
+
+					items = append(items, it)
+				}
+			}
+		}
+	}
+
+	errs = append(errs, validate(items)...)
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// validate is a function that returns an error for each accessor name or thing declared more than once.
+func validate(items []item) []error {
+	errs := make([]error, 0)
+
+	accessors := make(map[string]token.Position)
+	things := make(map[string]token.Position)
+	params := make(map[string]bool)
+
+	for i, it := range items {
+		if pos, ok := accessors[it.accessor]; ok {
+			errs = append(errs, fmt.Errorf("%s: accessor %s already declared at %s", it.pos, it.accessor, pos))
+			continue
+		}
+
+		accessors[it.accessor] = it.pos
+
+		thing := describe(it, nil)
+		if pos, ok := things[thing]; ok {
+			errs = append(errs, fmt.Errorf("%s: %s already declared at %s", it.pos, thing, pos))
+			continue
+		}
+
+		things[thing] = it.pos
+
+		if it.variable != "" {
+			continue
+		}
+
+		param := strings.TrimPrefix(it.accessor, "Equip")
+		if param == "" {
+			param = "thing"
+		}
+
+		param = string(unicode.ToLower(rune(param[0]))) + param[1:]
+
+		for token.IsKeyword(param) || params[param] || param == "opt" {
+			param += "_"
+		}
+
+		params[param] = true
+		items[i].param = param
+	}
+
+	return errs
+}
+
+// render is a function that returns the formatted source of the accessors of the given things in the given package.
+func render(pkg *types.Package, items []item, register string) ([]byte, error) {
+	imports := newImports(pkg)
+	qualifier := imports.qualifier
+	hoard := imports.names[hoardPath]
+
+	b := bytes.Buffer{}
+
+	b.WriteString("// Code generated by hoardgen. DO NOT EDIT.\n\n")
+	b.WriteString("package " + pkg.Name() + "\n\n")
+
+	// the imports are only known once the types are printed
+	body := bytes.Buffer{}
+
+	body.WriteString("var (\n")
+	for _, it := range items {
+		fmt.Fprintf(&body, "%s = %s.NewKey[%s](%q, %q)\n", it.key(), hoard, types.TypeString(it.typ, qualifier), it.inventory, it.name)
+	}
+	body.WriteString(")\n")
+
+	for _, it := range items {
+		fmt.Fprintf(&body, "\n// %s returns the %s.\n", it.accessor, describe(it, qualifier))
+		fmt.Fprintf(&body, "// It panics if the thing is not hoarded, refer to the [%s.Key.Equip] method.\n", hoard)
+		fmt.Fprintf(&body, "func %s(customHoarder ...%s.Hoarder) %s {\n", it.accessor, hoard, types.TypeString(it.typ, qualifier))
+		fmt.Fprintf(&body, "return %s.Equip(customHoarder...)\n", it.key())
+		body.WriteString("}\n")
+	}
+
+	params := make([]string, 0)
+	for _, it := range items {
+		if it.param != "" {
+			params = append(params, it.param+" "+types.TypeString(it.typ, qualifier))
+		}
+	}
+
+	fmt.Fprintf(&body, "\n// %s hoards every thing declared by a %s directive, with the given options.\n", register, directive)
+	fmt.Fprintf(&body, "// The variables are hoarded as they are when the function is called.\n")
+	fmt.Fprintf(&body, "func %s(%s) %s.Hoarder {\n", register, strings.Join(append([]string{"opt " + hoard + ".HoardOptions"}, params...), ", "), hoard)
+	fmt.Fprintf(&body, "return %s.Hoard(\nopt,\n", hoard)
+	for _, it := range items {
+		thing := it.variable
+		if thing == "" {
+			thing = it.param
+		}
+
+		fmt.Fprintf(&body, "%s.Wrap(%s),\n", it.key(), thing)
+	}
+	body.WriteString(")\n}\n")
+
+	b.WriteString("import (\n")
+	paths := imports.paths()
+	for i, path := range paths {
+		// the standard library is imported first, in its own group
+		if i > 0 && isStd(path) != isStd(paths[i-1]) {
+			b.WriteString("\n")
+		}
+
+		if name := imports.names[path]; name != imports.original[path] {
+			fmt.Fprintf(&b, "%s %q\n", name, path)
+			continue
+		}
+
+		fmt.Fprintf(&b, "%q\n", path)
+	}
+	b.WriteString(")\n\n")
+
+	b.Write(body.Bytes())
+
+	return format.Source(b.Bytes())
+}
+
+// imports is a struct that names the packages imported by the generated code.
+type imports struct {
+	pkg *types.Package
+
+	// names holds the name of each imported package in the generated code, by path.
+	names map[string]string
+
+	// original holds the name each imported package declares, by path.
+	original map[string]string
+
+	// taken holds the names already used in the generated code.
+	taken map[string]bool
+}
+
+func newImports(pkg *types.Package) *imports {
+	i := &imports{
+		pkg:      pkg,
+		names:    make(map[string]string),
+		original: make(map[string]string),
+		taken:    make(map[string]bool),
+	}
+
+	i.name(hoardPath, "hoard")
+
+	return i
+}
+
+// qualifier is a method that names the given package, importing it if necessary.
+func (i *imports) qualifier(p *types.Package) string {
+	if p.Path() == i.pkg.Path() {
+		return ""
+	}
+
+	return i.name(p.Path(), p.Name())
+}
+
+// name is a method that returns the name of the package with the given path, picking an unused one based on the given name if necessary.
+func (i *imports) name(path, name string) string {
+	if n, ok := i.names[path]; ok {
+		return n
+	}
+
+	n := name
+	for suffix := 2; i.taken[n] || n == i.pkg.Name(); suffix++ {
+		n = name + strconv.Itoa(suffix)
+	}
+
+	i.names[path] = n
+	i.original[path] = name
+	i.taken[n] = true
+
+	return n
+}
+
+// paths is a method that returns the paths of the imported packages, sorted with the standard library first.
+func (i *imports) paths() []string {
+	paths := make([]string, 0, len(i.names))
+	for path := range i.names {
+		paths = append(paths, path)
+	}
+
+	slices.SortFunc(paths, func(a, b string) int {
+		if isStd(a) != isStd(b) {
+			if isStd(a) {
+				return -1
+			}
+
+			return 1
+		}
+
+		return strings.Compare(a, b)
+	})
+
+	return paths
+}
+
+// isStd is a function that reports whether the given import path is the one of a package of the standard library.
+func isStd(path string) bool {
+	return !strings.Contains(strings.Split(path, "/")[0], ".")
+}
+
+// parse is a function that returns the thing declared by the given directive, without its type.
+func parse(text string, pos token.Position) (item, error) {
+	it := item{pos: pos}
+
+	fields, err := splitFields(strings.TrimPrefix(text, directive))
+	if err != nil {
+		return item{}, fmt.Errorf("%s: %w", pos, err)
+	}
+
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return item{}, fmt.Errorf("%s: expected key=value, found %q", pos, field)
+		}
+
+		if strings.HasPrefix(value, `"`) {
+			if value, err = strconv.Unquote(value); err != nil {
+				return item{}, fmt.Errorf("%s: invalid value of %s: %w", pos, key, err)
+			}
+		}
+
+		switch key {
+		case "name":
+			it.name = value
+		case "inventory":
+			it.inventory = value
+		case "accessor":
+			it.accessor = value
+		default:
+			return item{}, fmt.Errorf("%s: unknown key %q, expected name, inventory or accessor", pos, key)
+		}
+	}
+
+	return it, nil
+}
+
+// splitFields is a function that splits the given text around spaces, except within double quotes.
+func splitFields(text string) ([]string, error) {
+	fields := make([]string, 0)
+
+	field := strings.Builder{}
+	quoted, escaped := false, false
+
+	for _, r := range text {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case !quoted && unicode.IsSpace(r):
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+
+			continue
+		}
+
+		field.WriteRune(r)
+	}
+
+	if quoted {
+		return nil, errors.New("unterminated quoted value")
+	}
+
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+
+	return fields, nil
+}
+
+// directives is a function that returns the directives of the given comment group.
+func directives(doc *ast.CommentGroup) []*ast.Comment {
+	if doc == nil {
+		return nil
+	}
+
+	comments := make([]*ast.Comment, 0)
+	for _, c := range doc.List {
+		if c.Text == directive || strings.HasPrefix(c.Text, directive+" ") {
+			comments = append(comments, c)
+		}
+	}
+
+	return comments
+}
+
+// firstDoc is a function that returns the first non-nil comment group.
+func firstDoc(docs ...*ast.CommentGroup) *ast.CommentGroup {
+	for _, doc := range docs {
+		if doc != nil {
+			return doc
+		}
+	}
+
+	return nil
+}
+
+// describe is a function that returns a human-readable description of the given thing, with the packages named by the given qualifier.
+func describe(it item, qualifier types.Qualifier) string {
+	typ := types.TypeString(it.typ, qualifier)
+
+	if it.name != "" {
+		typ += " named " + strconv.Quote(it.name)
+	}
+
+	inventory := it.inventory
+	if inventory == "" {
+		inventory = "default"
+	}
+
+	return typ + " in inventory " + inventory
+}
+
+// typeName is a function that returns the name of the given type to be used in an accessor name, or an empty string if none.
+func typeName(typ types.Type) string {
+	if p, ok := typ.(*types.Pointer); ok {
+		typ = p.Elem()
+	}
+
+	switch typ := types.Unalias(typ).(type) {
+	case *types.Named:
+		return camelCase(typ.Obj().Name())
+	case *types.Basic:
+		return camelCase(typ.Name())
+	}
+
+	return ""
+}
+
+// accessorName is a function that returns the given item name in camel case followed by the given type name, unless the former already ends with the latter.
+// Example: "primary" and "DB" become "PrimaryDB", while "timeout" and "Timeout" become "Timeout".
+func accessorName(name, typeName string) string {
+	name = camelCase(name)
+
+	if strings.HasSuffix(strings.ToLower(name), strings.ToLower(typeName)) {
+		return name
+	}
+
+	return name + typeName
+}
+
+// camelCase is a function that returns the given name in camel case, starting with an upper case letter.
+// Example: "primary-db" becomes "PrimaryDb".
+func camelCase(name string) string {
+	b := strings.Builder{}
+
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oopchi/hoard"
+	"github.com/oopchi/hoard/cmd/hoardgen/testdata/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	dir := filepath.Join("testdata", "db")
+
+	want, err := os.ReadFile(filepath.Join(dir, "hoard_gen.go"))
+	require.NoError(t, err)
+
+	got, err := generate(dir, "hoard_gen.go", "HoardItems")
+	require.NoError(t, err)
+
+	require.Equal(t, string(want), string(got))
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		given   string
+		want    item
+		wantErr string
+	}{
+		{
+			name:  "should parse an empty directive",
+			given: "//hoard:item",
+			want:  item{},
+		},
+		{
+			name:  "should parse every key",
+			given: "//hoard:item name=primary inventory=databases accessor=EquipMain",
+			want:  item{name: "primary", inventory: "databases", accessor: "EquipMain"},
+		},
+		{
+			name:  "should parse a quoted value",
+			given: `//hoard:item name="read \"only\" replica"`,
+			want:  item{name: `read "only" replica`},
+		},
+		{
+			name:    "should fail on an unknown key",
+			given:   "//hoard:item label=primary",
+			wantErr: `db.go:3: unknown key "label", expected name, inventory or accessor`,
+		},
+		{
+			name:    "should fail on a missing value",
+			given:   "//hoard:item primary",
+			wantErr: `db.go:3: expected key=value, found "primary"`,
+		},
+		{
+			name:    "should fail on an unterminated quoted value",
+			given:   `//hoard:item name="primary`,
+			wantErr: "db.go:3: unterminated quoted value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos := token.Position{Filename: "db.go", Line: 3}
+
+			got, err := parse(tt.given, pos)
+
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+
+			tt.want.pos = pos
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAccessorName(t *testing.T) {
+	require.Equal(t, "PrimaryDB", accessorName("primary", "DB"))
+	require.Equal(t, "ReadOnlyReplicaDB", accessorName("read-only replica", "DB"))
+	require.Equal(t, "Timeout", accessorName("timeout", "Timeout"))
+	require.Equal(t, "Config", accessorName("", "Config"))
+}
+
+func TestValidate(t *testing.T) {
+	items := []item{
+		{typ: types.Typ[types.Int], accessor: "EquipAnswer", pos: token.Position{Filename: "db.go", Line: 1}},
+		{typ: types.Typ[types.Int], accessor: "EquipAnswer", name: "answer", pos: token.Position{Filename: "db.go", Line: 2}},
+		{typ: types.Typ[types.Int], accessor: "EquipInt", pos: token.Position{Filename: "db.go", Line: 3}},
+		{typ: types.Typ[types.String], accessor: "EquipOpt", pos: token.Position{Filename: "db.go", Line: 4}},
+	}
+
+	errs := validate(items)
+
+	require.Len(t, errs, 2)
+	require.EqualError(t, errs[0], "db.go:2: accessor EquipAnswer already declared at db.go:1")
+	require.EqualError(t, errs[1], "db.go:3: int in inventory default already declared at db.go:1")
+
+	require.Equal(t, "answer", items[0].param)
+	require.Equal(t, "opt_", items[3].param)
+}
+
+func TestHoardItems(t *testing.T) {
+	opt := hoard.HoardOptions{}.ShouldReplaceGlobal(false)
+
+	h := db.HoardItems(opt, db.Config{DSN: "plain"}, db.Config{DSN: "read only"}, 1, "type")
+
+	require.Equal(t, "plain", db.EquipConfig(h).DSN)
+	require.Equal(t, "read only", db.EquipReadOnlyConfig(h).DSN)
+
+	// the keys of the same type are equipped exactly whatever the order of the directives
+	tests := []struct {
+		name          string
+		inventory     string
+		readOnlyFirst bool
+	}{
+		{name: "should equip the unnamed key wrapped before the named one", inventory: "", readOnlyFirst: false},
+		{name: "should equip the unnamed key wrapped after the named one", inventory: "", readOnlyFirst: true},
+		{name: "should equip the unnamed key wrapped before the named one in a custom inventory", inventory: "databases", readOnlyFirst: false},
+		{name: "should equip the unnamed key wrapped after the named one in a custom inventory", inventory: "databases", readOnlyFirst: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plain := hoard.NewKey[db.Config](tt.inventory, "")
+			readOnly := hoard.NewKey[db.Config](tt.inventory, "read only")
+
+			things := []interface{}{plain.Wrap(db.Config{DSN: "plain"}), readOnly.Wrap(db.Config{DSN: "read only"})}
+			if tt.readOnlyFirst {
+				things[0], things[1] = things[1], things[0]
+			}
+
+			h := hoard.Hoard(opt, things...)
+
+			require.Equal(t, "plain", plain.Equip(h).DSN)
+			require.Equal(t, "read only", readOnly.Equip(h).DSN)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Command hoardgen generates strongly typed accessors for the things hoarded by a package.
+//
+// The things are declared by //hoard:item directives on package-level types or variables:
+//
+//	//go:generate go run github.com/oopchi/hoard/cmd/hoardgen
+//
+//	//hoard:item name=primary inventory=databases
+//	var PrimaryDB *sql.DB
+//
+//	//hoard:item
+//	type Config struct {
+//		Port int
+//	}
+//
+// For each directive, hoardgen generates an accessor, such as:
+//
+//	func EquipPrimaryDB(customHoarder ...hoard.Hoarder) *sql.DB
+//	func EquipConfig(customHoarder ...hoard.Hoarder) Config
+//
+// along with a single registration function hoarding the variables and the given values of the types:
+//
+//	func HoardItems(opt hoard.HoardOptions, config Config) hoard.Hoarder
+//
+// The generated code uses a [hoard.Key] computed once per thing, hence the things are looked up under their exact name without any string building,
+// and a typo in a custom item name or a custom inventory name becomes a compile error instead of a runtime panic.
+//
+// The directive accepts the following space-separated key=value pairs, each being optional:
+//   - name: the custom item name of the thing, refer to the [hoard.RememberAs] function,
+//   - inventory: the custom inventory name of the thing, refer to the [hoard.UseInventory] function,
+//   - accessor: the name of the generated accessor, by default "Equip" followed by the name in camel case and the type name.
+//
+// Values containing spaces can be quoted with double quotes.
+//
+// Usage:
+//
+//	hoardgen [-output hoard_gen.go] [-register HoardItems] [dir]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+var (
+	output   = flag.String("output", "hoard_gen.go", "name of the generated file, written into the package directory")
+	register = flag.String("register", "HoardItems", "name of the generated registration function")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("hoardgen: ")
+
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: hoardgen [flags] [dir]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	src, err := generate(dir, *output, *register)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, *output), src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+//go:generate go run github.com/oopchi/hoard/cmd/hoardgen
+
+//hoard:item name=primary inventory=databases
+var PrimaryDB *sql.DB
+
+var (
+	//hoard:item name=replica inventory=databases
+	ReplicaDB *sql.DB
+
+	//hoard:item accessor=EquipHTTPClient
+	client = http.DefaultClient
+)
+
+// Config is the configuration of the database.
+//
+//hoard:item
+//hoard:item name="read only"
+type Config struct {
+	DSN string
+}
+
+//hoard:item name=timeout
+type Timeout int
+
+//hoard:item name=type
+type Type string
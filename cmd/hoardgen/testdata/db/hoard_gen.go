@@ -0,0 +1,77 @@
+// Code generated by hoardgen. DO NOT EDIT.
+
+package db
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/oopchi/hoard"
+)
+
+var (
+	keyPrimaryDB      = hoard.NewKey[*sql.DB]("databases", "primary")
+	keyReplicaDB      = hoard.NewKey[*sql.DB]("databases", "replica")
+	keyHTTPClient     = hoard.NewKey[*http.Client]("", "")
+	keyConfig         = hoard.NewKey[Config]("", "")
+	keyReadOnlyConfig = hoard.NewKey[Config]("", "read only")
+	keyTimeout        = hoard.NewKey[Timeout]("", "timeout")
+	keyType           = hoard.NewKey[Type]("", "type")
+)
+
+// EquipPrimaryDB returns the *sql.DB named "primary" in inventory databases.
+// It panics if the thing is not hoarded, refer to the [hoard.Key.Equip] method.
+func EquipPrimaryDB(customHoarder ...hoard.Hoarder) *sql.DB {
+	return keyPrimaryDB.Equip(customHoarder...)
+}
+
+// EquipReplicaDB returns the *sql.DB named "replica" in inventory databases.
+// It panics if the thing is not hoarded, refer to the [hoard.Key.Equip] method.
+func EquipReplicaDB(customHoarder ...hoard.Hoarder) *sql.DB {
+	return keyReplicaDB.Equip(customHoarder...)
+}
+
+// EquipHTTPClient returns the *http.Client in inventory default.
+// It panics if the thing is not hoarded, refer to the [hoard.Key.Equip] method.
+func EquipHTTPClient(customHoarder ...hoard.Hoarder) *http.Client {
+	return keyHTTPClient.Equip(customHoarder...)
+}
+
+// EquipConfig returns the Config in inventory default.
+// It panics if the thing is not hoarded, refer to the [hoard.Key.Equip] method.
+func EquipConfig(customHoarder ...hoard.Hoarder) Config {
+	return keyConfig.Equip(customHoarder...)
+}
+
+// EquipReadOnlyConfig returns the Config named "read only" in inventory default.
+// It panics if the thing is not hoarded, refer to the [hoard.Key.Equip] method.
+func EquipReadOnlyConfig(customHoarder ...hoard.Hoarder) Config {
+	return keyReadOnlyConfig.Equip(customHoarder...)
+}
+
+// EquipTimeout returns the Timeout named "timeout" in inventory default.
+// It panics if the thing is not hoarded, refer to the [hoard.Key.Equip] method.
+func EquipTimeout(customHoarder ...hoard.Hoarder) Timeout {
+	return keyTimeout.Equip(customHoarder...)
+}
+
+// EquipType returns the Type named "type" in inventory default.
+// It panics if the thing is not hoarded, refer to the [hoard.Key.Equip] method.
+func EquipType(customHoarder ...hoard.Hoarder) Type {
+	return keyType.Equip(customHoarder...)
+}
+
+// HoardItems hoards every thing declared by a //hoard:item directive, with the given options.
+// The variables are hoarded as they are when the function is called.
+func HoardItems(opt hoard.HoardOptions, config Config, readOnlyConfig Config, timeout Timeout, type_ Type) hoard.Hoarder {
+	return hoard.Hoard(
+		opt,
+		keyPrimaryDB.Wrap(PrimaryDB),
+		keyReplicaDB.Wrap(ReplicaDB),
+		keyHTTPClient.Wrap(client),
+		keyConfig.Wrap(config),
+		keyReadOnlyConfig.Wrap(readOnlyConfig),
+		keyTimeout.Wrap(timeout),
+		keyType.Wrap(type_),
+	)
+}
@@ -37,6 +37,7 @@ func ExampleEquipWithOption_inventories() {
 	// You can even skip the annotation whatsoever if there has only ever been one such item being hoarded even if its annotated
 	// If there were already multiple such items being hoarded though, if its being hoarded through a custom inventory
 	// then it won't override the one at the default inventory anymore, however it will still override the one at that custom inventory if any existed
+	// This is the [hoard.ShadowIfAbsent] mode, use [hoard.WithShadowMode] to keep an inventory isolated or to always export its items instead
 	svc3 := hoard.EquipDefault[ServiceImpl]()
 
 	// You can however override the default inventory again if you specifically hoard on the default inventory (hoarding without specifying [hoard.UseInventory])
@@ -50,3 +51,19 @@ func ExampleEquipWithOption_inventories() {
 	//  Executing Service with ID: 1
 	//  Executing Service with ID: 8
 }
+
+func ExampleWithShadowMode() {
+	// Keep the items of a custom Inventory out of the default inventory
+	h := hoard.Hoard(hoard.HoardOptions{}.ShouldReplaceGlobal(false),
+		hoard.UseInventory("isolated items inventory", hoard.WithShadowMode(hoard.ShadowIsolated)).
+			Put(hoard.RememberAs(ServiceImpl{ID: 1}, "impl1")),
+	)
+
+	// The items can only be equipped from their own inventory
+	svc1 := hoard.EquipWithOption[SMyService](hoard.EquipOptions{}.WithCustomItemName("impl1").WithCustomInventoryName("isolated items inventory"), h)
+	svc2 := hoard.EquipOr[SMyService](hoard.EquipOptions{}.WithCustomItemName("impl1"), ServiceImpl{ID: 0}, h)
+
+	fmt.Println(svc1.Execute(), svc2.Execute())
+	// Output: Executing Service with ID: 1
+	//  Executing Service with ID: 0
+}
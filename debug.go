@@ -0,0 +1,199 @@
+package hoard
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// redactedValue is shown by the [DebugHandler] in place of the value of a thing that has not been revealed.
+	redactedValue = "[redacted]"
+)
+
+// Reveal is a function that wraps the given thing into an [Item] whose value is shown by the [DebugHandler].
+// The values of the other things are redacted.
+// The given thing may be an [Item] created by the [RememberAs] function, which keeps its custom name.
+// Example usage:
+//
+//	Hoard(nil, Reveal(featureFlags), Reveal(RememberAs(region, "region")))
+//	Hoard(nil, UseInventory("config").Put(Reveal(RememberAs(timeout, "timeout"))))
+func Reveal(thing interface{}) Item {
+	if thing == nil {
+		return nil
+	}
+
+	item, ok := thing.(Item)
+	if !ok {
+		item = newItem(thing, getThingName(getTypeOfThing(thing)))
+	}
+
+	meta := item.getMeta()
+	meta.revealed = true
+
+	return &itemImpl{
+		thing: item.use(),
+		name:  item.getName(),
+		meta:  meta,
+	}
+}
+
+// debugView is a struct that describes every [Inventory] of a [Hoarder] as served by the [DebugHandler].
+// This struct is used internally and should not be used directly.
+type debugView struct {
+	Inventories []debugInventory `json:"inventories"`
+}
+
+// debugInventory is a struct that describes an [Inventory] as served by the [DebugHandler].
+// This struct is used internally and should not be used directly.
+type debugInventory struct {
+	Name  string      `json:"name"`
+	Items []debugItem `json:"items"`
+}
+
+// debugItem is a struct that describes a slot of an [Inventory] as served by the [DebugHandler].
+// This struct is used internally and should not be used directly.
+type debugItem struct {
+	Key      string    `json:"key"`
+	Type     string    `json:"type"`
+	Name     string    `json:"name"`
+	Kind     string    `json:"kind"`
+	Version  uint64    `json:"version"`
+	Caller   string    `json:"caller"`
+	At       time.Time `json:"at"`
+	ShadowOf string    `json:"shadow_of"`
+	Revealed bool      `json:"revealed"`
+	Value    string    `json:"value"`
+}
+
+// DebugHandler is a function that returns an [http.Handler] serving a live view of every [Inventory] of the given [Hoarder],
+// similar to the handlers of the net/http/pprof package.
+// The global [Hoarder] is served if the given [Hoarder] is nil.
+//
+// Each slot is described by its key, the type of the thing it holds, its custom [Item] name, its version,
+// the file and line of the code that last changed it, and the custom [Inventory] it has been copied from if it is a shadow copy in the default [Inventory].
+// The value of the thing is redacted unless it has been hoarded through the [Reveal] function.
+//
+// The view is served as HTML, or as JSON if the request has the "format=json" query parameter or accepts "application/json".
+//
+// Example usage:
+//
+//	http.Handle("/debug/hoard", DebugHandler(nil))
+func DebugHandler(h Hoarder) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		view := newDebugView(pickHoarder(h))
+
+		if r.URL.Query().Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json") {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+
+			_ = enc.Encode(view)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+		_ = debugTemplate.Execute(w, view)
+	})
+}
+
+// newDebugView is a function that returns the [debugView] describing the given [Hoarder].
+func newDebugView(h Hoarder) debugView {
+	view := debugView{
+		Inventories: make([]debugInventory, 0),
+	}
+
+	for k, v := range h.loadout() {
+		inventory := debugInventory{
+			Name:  getOriginalInventoryName(k),
+			Items: make([]debugItem, 0),
+		}
+
+		for name, r := range v.records() {
+			thing := r.item.use()
+			if thing == nil {
+				continue
+			}
+
+			typeOfThing := reflect.TypeOf(thing)
+
+			item := debugItem{
+				Key:      name,
+				Version:  r.version,
+				Caller:   r.caller(),
+				At:       r.at,
+				Revealed: r.item.getMeta().revealed,
+				Value:    redactedValue,
+			}
+
+			item.Type, item.Name = getItemTypeAndName(name, typeOfThing)
+			item.Kind = typeOfThing.Kind().String()
+
+			if r.shadowOf != "" {
+				item.ShadowOf = getOriginalInventoryName(r.shadowOf)
+			}
+
+			if item.Revealed {
+				item.Value = fmt.Sprintf("%+v", thing)
+			}
+
+			inventory.Items = append(inventory.Items, item)
+		}
+
+		view.Inventories = append(view.Inventories, inventory)
+	}
+
+	slices.SortFunc(view.Inventories, func(a, b debugInventory) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return view
+}
+
+// debugTemplate is the template of the HTML view served by the [DebugHandler].
+var debugTemplate = template.Must(template.New("hoard").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>hoard</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+td.key { font-family: monospace; white-space: pre; }
+.muted { color: #888; }
+</style>
+</head>
+<body>
+<h1>hoard</h1>
+<p><a href="?format=json">json</a></p>
+{{range .Inventories}}
+<h2>inventory {{.Name}}</h2>
+<table>
+<tr><th>key</th><th>type</th><th>name</th><th>kind</th><th>version</th><th>caller</th><th>at</th><th>shadow of</th><th>value</th></tr>
+{{range .Items}}
+<tr>
+<td class="key">{{.Key}}</td>
+<td>{{.Type}}</td>
+<td>{{.Name}}</td>
+<td>{{.Kind}}</td>
+<td>{{.Version}}</td>
+<td>{{.Caller}}</td>
+<td>{{if not .At.IsZero}}{{.At.Format "2006-01-02T15:04:05.000Z07:00"}}{{end}}</td>
+<td>{{.ShadowOf}}</td>
+<td{{if not .Revealed}} class="muted"{{end}}>{{.Value}}</td>
+</tr>
+{{end}}
+</table>
+{{end}}
+</body>
+</html>
+`))
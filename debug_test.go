@@ -0,0 +1,146 @@
+package hoard
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/stretchr/testify/require"
+)
+
+func (s *suiteTest) TestReveal() {
+	tests := []struct {
+		name     string
+		given    interface{}
+		wantName string
+		wantNil  bool
+	}{
+		{
+			name:     "should name a thing after its type",
+			given:    "test",
+			wantName: "string",
+		},
+		{
+			name:     "should keep the custom name of an item",
+			given:    RememberAs("test", "test"),
+			wantName: "string\ntest",
+		},
+		{
+			name:    "should return nil for a nil thing",
+			given:   nil,
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			got := Reveal(tt.given)
+
+			if tt.wantNil {
+				require.Nil(s.T(), got)
+				return
+			}
+
+			require.Equal(s.T(), tt.wantName, got.getName())
+			require.Equal(s.T(), "test", got.use())
+			require.True(s.T(), got.getMeta().revealed)
+		})
+	}
+}
+
+func (s *suiteTest) TestDebugHandler() {
+	h := factory()
+
+	Hoard(
+		HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h),
+		"secret",
+		Reveal(RememberAs(42, "answer")),
+		UseInventory("test").Put(RememberAs(&TestFooImpl{Name: "foo"}, "")),
+	)
+
+	s.Run("should serve json", func() {
+		rec := httptest.NewRecorder()
+
+		DebugHandler(h).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/hoard?format=json", nil))
+
+		require.Equal(s.T(), "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+
+		got := debugView{}
+		require.NoError(s.T(), json.Unmarshal(rec.Body.Bytes(), &got))
+
+		require.Len(s.T(), got.Inventories, 2)
+		require.Equal(s.T(), "default", got.Inventories[0].Name)
+		require.Equal(s.T(), "test", got.Inventories[1].Name)
+
+		items := map[string]debugItem{}
+		for _, item := range got.Inventories[0].Items {
+			items[item.Key] = item
+
+			require.True(s.T(), strings.Contains(item.Caller, "debug_test.go:"), item.Caller)
+			require.False(s.T(), item.At.IsZero())
+		}
+
+		require.Equal(s.T(), "string", items["string"].Type)
+		require.Equal(s.T(), "", items["string"].Name)
+		require.Equal(s.T(), redactedValue, items["string"].Value)
+		require.False(s.T(), items["string"].Revealed)
+
+		require.Equal(s.T(), "int", items["answer"].Type)
+		require.Equal(s.T(), "answer", items["answer"].Name)
+		require.Equal(s.T(), "42", items["answer"].Value)
+		require.True(s.T(), items["answer"].Revealed)
+
+		require.Equal(s.T(), "answer", items["int\nanswer"].Name)
+
+		shadow := items[getThingName(getTypeOfThing(&TestFooImpl{}))]
+		require.Equal(s.T(), "*hoard.TestFooImpl", shadow.Type)
+		require.Equal(s.T(), "ptr", shadow.Kind)
+		require.Equal(s.T(), "test", shadow.ShadowOf)
+		require.Equal(s.T(), redactedValue, shadow.Value)
+
+		require.Len(s.T(), got.Inventories[1].Items, 1)
+		require.Equal(s.T(), "", got.Inventories[1].Items[0].ShadowOf)
+	})
+
+	s.Run("should serve json if accepted", func() {
+		rec := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodGet, "/debug/hoard", nil)
+		req.Header.Set("Accept", "application/json")
+
+		DebugHandler(h).ServeHTTP(rec, req)
+
+		require.Equal(s.T(), "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+	})
+
+	s.Run("should serve html", func() {
+		rec := httptest.NewRecorder()
+
+		DebugHandler(h).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/hoard", nil))
+
+		require.Equal(s.T(), "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+
+		body := rec.Body.String()
+
+		require.Contains(s.T(), body, "<h2>inventory default</h2>")
+		require.Contains(s.T(), body, "<h2>inventory test</h2>")
+		require.Contains(s.T(), body, "*hoard.TestFooImpl")
+		require.Contains(s.T(), body, ">42<")
+		require.NotContains(s.T(), body, "secret")
+		require.NotContains(s.T(), body, "foo")
+	})
+}
+
+func (s *suiteTest) TestDebugHandler_nilThing() {
+	h := Hoard(HoardOptions{}.ShouldReplaceGlobal(false), RememberAs(nil, "x"), Reveal(1))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/?format=json", nil)
+
+	require.NotPanics(s.T(), func() {
+		DebugHandler(h).ServeHTTP(rec, req)
+	})
+	require.Equal(s.T(), http.StatusOK, rec.Code)
+	require.NotContains(s.T(), rec.Body.String(), "<nil>")
+}
@@ -0,0 +1,219 @@
+package hoard
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// HoarderDiff is a struct that describes the differences between two [Hoarder].
+// The struct is returned by the [Diff] function.
+type HoarderDiff struct {
+
+	// Inventories holds the differences of each [Inventory] that differs, sorted by name.
+	Inventories []InventoryDiff
+}
+
+// InventoryDiff is a struct that describes the differences between the slots of an [Inventory] of two [Hoarder].
+type InventoryDiff struct {
+
+	// Inventory is the name of the [Inventory], as given to the [UseInventory] function, or "default" for the default one.
+	Inventory string
+
+	// Added holds the slots only found in the second [Hoarder], sorted by key.
+	Added []Slot
+
+	// Removed holds the slots only found in the first [Hoarder], sorted by key.
+	Removed []Slot
+
+	// Changed holds the slots found in both [Hoarder] but holding a different thing, sorted by key.
+	Changed []ChangedSlot
+}
+
+// ChangedSlot is a struct that describes a slot found in both [Hoarder] but holding a different thing.
+// The things differ if their types differ, or if they are not the same value:
+// pointers, maps, slices, channels and functions must point to the same memory, other values must be equal.
+type ChangedSlot struct {
+
+	// Before is the slot in the first [Hoarder].
+	Before Slot
+
+	// After is the slot in the second [Hoarder].
+	After Slot
+}
+
+// Diff is a function that returns the differences between the slots of the given [Hoarder], going from a to b.
+// The global [Hoarder] is used if a given [Hoarder] is nil.
+// Slots are compared by [Inventory] and key, and the things they hold by identity, not deeply.
+//
+// Example usage:
+//
+//	production := Hoard(HoardOptions{}.ShouldReplaceGlobal(false), db, cache)
+//	test := Hoard(HoardOptions{}.ShouldReplaceGlobal(false), fakeDB, cache)
+//
+//	fmt.Println(Diff(production, test))
+func Diff(a, b Hoarder) HoarderDiff {
+	type slotKey struct {
+		inventory string
+		key       string
+	}
+
+	before := make(map[slotKey]slotEntry)
+	for _, e := range slotEntries(a) {
+		before[slotKey{inventory: e.slot.Inventory, key: e.slot.Key}] = e
+	}
+
+	after := make(map[slotKey]slotEntry)
+	for _, e := range slotEntries(b) {
+		after[slotKey{inventory: e.slot.Inventory, key: e.slot.Key}] = e
+	}
+
+	inventories := make(map[string]*InventoryDiff)
+	inventoryOf := func(name string) *InventoryDiff {
+		if _, ok := inventories[name]; !ok {
+			inventories[name] = &InventoryDiff{
+				Inventory: name,
+				Added:     make([]Slot, 0),
+				Removed:   make([]Slot, 0),
+				Changed:   make([]ChangedSlot, 0),
+			}
+		}
+
+		return inventories[name]
+	}
+
+	for k, x := range before {
+		y, ok := after[k]
+
+		switch {
+		case !ok:
+			d := inventoryOf(k.inventory)
+			d.Removed = append(d.Removed, x.slot)
+		case !sameThing(x.thing, y.thing):
+			d := inventoryOf(k.inventory)
+			d.Changed = append(d.Changed, ChangedSlot{Before: x.slot, After: y.slot})
+		}
+	}
+
+	for k, y := range after {
+		if _, ok := before[k]; !ok {
+			d := inventoryOf(k.inventory)
+			d.Added = append(d.Added, y.slot)
+		}
+	}
+
+	diff := HoarderDiff{
+		Inventories: make([]InventoryDiff, 0, len(inventories)),
+	}
+
+	for _, d := range inventories {
+		slices.SortFunc(d.Added, func(x, y Slot) int {
+			return cmp.Compare(x.Key, y.Key)
+		})
+
+		slices.SortFunc(d.Removed, func(x, y Slot) int {
+			return cmp.Compare(x.Key, y.Key)
+		})
+
+		slices.SortFunc(d.Changed, func(x, y ChangedSlot) int {
+			return cmp.Compare(x.Before.Key, y.Before.Key)
+		})
+
+		diff.Inventories = append(diff.Inventories, *d)
+	}
+
+	slices.SortFunc(diff.Inventories, func(x, y InventoryDiff) int {
+		return cmp.Compare(x.Inventory, y.Inventory)
+	})
+
+	return diff
+}
+
+// sameThing is a function that reports whether the given things are the same value of the same type.
+func sameThing(a, b interface{}) bool {
+	x, y := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	if !x.IsValid() || !y.IsValid() {
+		return x.IsValid() == y.IsValid()
+	}
+
+	if x.Type() != y.Type() {
+		return false
+	}
+
+	switch x.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return x.Pointer() == y.Pointer()
+	case reflect.Slice:
+		return x.Pointer() == y.Pointer() && x.Len() == y.Len()
+	}
+
+	if x.Type().Comparable() {
+		// a struct or an array may still hold an uncomparable interface value
+		return func() (same bool) {
+			defer func() {
+				if recover() != nil {
+					same = reflect.DeepEqual(a, b)
+				}
+			}()
+
+			return a == b
+		}()
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// Empty is a method that reports whether the [HoarderDiff] holds no difference.
+func (d HoarderDiff) Empty() bool {
+	return len(d.Inventories) == 0
+}
+
+// String is a method that returns a human-readable description of the [HoarderDiff].
+// Added slots are prefixed with "+", removed slots with "-" and changed slots with "~".
+// Example output:
+//
+//	hoard: 1 slot added, 1 slot removed, 2 slots changed
+//	inventory default:
+//		+ "*github.com/acme/cacheLRU" *cache.LRU
+//		- "*github.com/acme/cacheRedis" *cache.Redis
+//		~ "*github.com/acme/dbDB" *db.DB, same type but another value
+//		~ "clock" *time.Clock -> *fake.Clock
+func (d HoarderDiff) String() string {
+	added, removed, changed := 0, 0, 0
+	for _, i := range d.Inventories {
+		added += len(i.Added)
+		removed += len(i.Removed)
+		changed += len(i.Changed)
+	}
+
+	b := strings.Builder{}
+
+	b.WriteString("hoard: " + plural(added, "slot") + " added, " + plural(removed, "slot") + " removed, " + plural(changed, "slot") + " changed")
+
+	for _, i := range d.Inventories {
+		b.WriteString("\ninventory " + i.Inventory + ":")
+
+		for _, s := range i.Added {
+			b.WriteString("\n\t+ " + strconv.Quote(s.Key) + " " + s.Type)
+		}
+
+		for _, s := range i.Removed {
+			b.WriteString("\n\t- " + strconv.Quote(s.Key) + " " + s.Type)
+		}
+
+		for _, c := range i.Changed {
+			b.WriteString("\n\t~ " + strconv.Quote(c.Before.Key) + " " + c.Before.Type)
+
+			if c.Before.Type != c.After.Type {
+				b.WriteString(" -> " + c.After.Type)
+			} else {
+				b.WriteString(", same type but another value")
+			}
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,141 @@
+package hoard
+
+import (
+	"github.com/stretchr/testify/require"
+)
+
+type testDiffDB struct {
+	dsn string
+}
+
+type testDiffFakeDB struct{}
+
+func (s *suiteTest) TestDiff() {
+	db := &testDiffDB{}
+	hosts := []string{"a"}
+	opt := HoardOptions{}.ShouldReplaceGlobal(false)
+
+	production := Hoard(opt,
+		db,
+		42,
+		RememberAs(hosts, "hosts"),
+		UseInventory("cache").Put(RememberAs("redis", "backend")),
+	)
+
+	test := Hoard(opt,
+		db,
+		43,
+		RememberAs(hosts, "hosts"),
+		UseInventory("cache").Put(RememberAs(&testDiffFakeDB{}, "backend")),
+		UseInventory("queue").Put(RememberAs(true, "")),
+	)
+
+	diff := Diff(production, test)
+
+	require.Equal(s.T(), []InventoryDiff{
+		{
+			Inventory: "cache",
+			Added: []Slot{
+				{Inventory: "cache", Key: "*github.com/oopchi/hoardtestDiffFakeDB", Type: "*hoard.testDiffFakeDB"},
+				{Inventory: "cache", Key: "*github.com/oopchi/hoardtestDiffFakeDB\nbackend", Type: "*hoard.testDiffFakeDB", Name: "backend"},
+			},
+			Removed: []Slot{
+				{Inventory: "cache", Key: "string", Type: "string"},
+				{Inventory: "cache", Key: "string\nbackend", Type: "string", Name: "backend"},
+			},
+			Changed: []ChangedSlot{
+				{
+					Before: Slot{Inventory: "cache", Key: "backend", Type: "string", Name: "backend"},
+					After:  Slot{Inventory: "cache", Key: "backend", Type: "*hoard.testDiffFakeDB", Name: "backend"},
+				},
+			},
+		},
+		{
+			Inventory: "default",
+			Added: []Slot{
+				{Inventory: "default", Key: "*github.com/oopchi/hoardtestDiffFakeDB", Type: "*hoard.testDiffFakeDB", ShadowOf: "cache"},
+				{Inventory: "default", Key: "*github.com/oopchi/hoardtestDiffFakeDB\nbackend", Type: "*hoard.testDiffFakeDB", Name: "backend", ShadowOf: "cache"},
+				{Inventory: "default", Key: "bool", Type: "bool", ShadowOf: "queue"},
+			},
+			Removed: []Slot{
+				{Inventory: "default", Key: "string", Type: "string", ShadowOf: "cache"},
+				{Inventory: "default", Key: "string\nbackend", Type: "string", Name: "backend", ShadowOf: "cache"},
+			},
+			Changed: []ChangedSlot{
+				{
+					Before: Slot{Inventory: "default", Key: "backend", Type: "string", Name: "backend", ShadowOf: "cache"},
+					After:  Slot{Inventory: "default", Key: "backend", Type: "*hoard.testDiffFakeDB", Name: "backend", ShadowOf: "cache"},
+				},
+				{
+					Before: Slot{Inventory: "default", Key: "int", Type: "int"},
+					After:  Slot{Inventory: "default", Key: "int", Type: "int"},
+				},
+			},
+		},
+		{
+			Inventory: "queue",
+			Added: []Slot{
+				{Inventory: "queue", Key: "bool", Type: "bool"},
+			},
+			Removed: []Slot{},
+			Changed: []ChangedSlot{},
+		},
+	}, diff.Inventories)
+
+	require.False(s.T(), diff.Empty())
+	require.True(s.T(), Diff(production, production).Empty())
+}
+
+func (s *suiteTest) TestDiff_String() {
+	opt := HoardOptions{}.ShouldReplaceGlobal(false)
+
+	a := Hoard(opt, &testDiffDB{}, 42, RememberAs("x", "clock"))
+	b := Hoard(opt, &testDiffDB{}, true, RememberAs(1, "clock"))
+
+	require.Equal(s.T(), `hoard: 2 slots added, 2 slots removed, 3 slots changed
+inventory default:
+	+ "bool" bool
+	+ "int\nclock" int
+	- "string" string
+	- "string\nclock" string
+	~ "*github.com/oopchi/hoardtestDiffDB" *hoard.testDiffDB, same type but another value
+	~ "clock" string -> int
+	~ "int" int, same type but another value`, Diff(a, b).String())
+
+	require.Equal(s.T(), "hoard: 0 slots added, 0 slots removed, 0 slots changed", Diff(a, a).String())
+}
+
+func (s *suiteTest) TestDiff_sameThing() {
+	type holder struct {
+		value interface{}
+	}
+
+	db := &testDiffDB{}
+	hosts := []string{"a", "b"}
+	ch := make(chan int)
+
+	tests := []struct {
+		name string
+		a    interface{}
+		b    interface{}
+		want bool
+	}{
+		{name: "should be the same pointer", a: db, b: db, want: true},
+		{name: "should not be the same pointer", a: db, b: &testDiffDB{}, want: false},
+		{name: "should be the same slice", a: hosts, b: hosts, want: true},
+		{name: "should not be the same slice if shorter", a: hosts, b: hosts[:1], want: false},
+		{name: "should not be the same slice if another one", a: hosts, b: []string{"a", "b"}, want: false},
+		{name: "should be the same channel", a: ch, b: ch, want: true},
+		{name: "should be equal values", a: 42, b: 42, want: true},
+		{name: "should not be values of another type", a: 42, b: int64(42), want: false},
+		{name: "should be equal structs holding an uncomparable value", a: holder{value: hosts}, b: holder{value: hosts}, want: true},
+		{name: "should be both nil", a: nil, b: nil, want: true},
+		{name: "should not be nil and a value", a: nil, b: 42, want: false},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			require.Equal(s.T(), tt.want, sameThing(tt.a, tt.b))
+		})
+	}
+}
@@ -5,6 +5,7 @@ go 1.23
 require (
 	github.com/stretchr/testify v1.9.0
 	go.uber.org/fx v1.22.2
+	golang.org/x/tools v0.26.0
 )
 
 require (
@@ -13,6 +14,8 @@ require (
 	go.uber.org/dig v1.18.0 // indirect
 	go.uber.org/multierr v1.10.0 // indirect
 	go.uber.org/zap v1.26.0 // indirect
-	golang.org/x/sys v0.0.0-20220412211240-33da011f77ad // indirect
+	golang.org/x/mod v0.21.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+	golang.org/x/sys v0.26.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
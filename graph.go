@@ -0,0 +1,375 @@
+package hoard
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DependencyGraph is a struct that describes how the things hoarded by a [Hoarder] reference each other.
+// The struct is returned by the [Graph] function.
+type DependencyGraph struct {
+
+	// Nodes holds the hoarded things sorted by inventory, type and name, followed by the things that are only referenced.
+	Nodes []GraphNode
+
+	// Edges holds the references between the things, sorted by source, target and field.
+	Edges []GraphEdge
+}
+
+// GraphNode is a struct that describes a thing of a [DependencyGraph].
+type GraphNode struct {
+
+	// ID is the identifier of the node within the [DependencyGraph].
+	ID string
+
+	// Inventory is the name of the [Inventory] the thing was hoarded into, as given to the [UseInventory] function, or "default" for the default one.
+	// Inventory is empty if the thing is not hoarded.
+	Inventory string
+
+	// Type is the type of the thing.
+	Type string
+
+	// Name is the custom [Item] name of the thing, or an empty string if none.
+	Name string
+
+	// Hoarded reports whether the thing is hoarded.
+	// A thing that is not hoarded is referenced by a field of a hoarded thing but cannot be resolved from the [Hoarder].
+	Hoarded bool
+}
+
+// GraphEdge is a struct that describes a reference of a [DependencyGraph], from a field of a hoarded thing to another thing.
+type GraphEdge struct {
+
+	// From is the identifier of the node holding the field.
+	From string
+
+	// To is the identifier of the node the field references.
+	To string
+
+	// Field is the name of the field.
+	Field string
+}
+
+// graphThing is a struct that describes a hoarded thing while the [DependencyGraph] is built.
+type graphThing struct {
+	node GraphNode
+
+	// inventoryName is the internal name of the [Inventory] the thing was hoarded into.
+	inventoryName string
+
+	// thing is the hoarded thing.
+	thing interface{}
+}
+
+// graphSlot is a struct that identifies a slot of a [Hoarder] while the [DependencyGraph] is built.
+type graphSlot struct {
+	inventoryName string
+	name          string
+}
+
+// Graph is a function that returns the [DependencyGraph] of the things hoarded by the given [Hoarder].
+// The global [Hoarder] is used if the given [Hoarder] is nil.
+//
+// A hoarded thing references another thing through each of its struct fields, exported or not,
+// that is either a pointer to a named type or a non-empty interface.
+// The referenced thing is resolved from the [Inventory] of the referencing thing, then from the default [Inventory],
+// the same way as the [EquipDefault] function does, using the dynamic type of a non-nil interface field first.
+// A field that cannot be resolved is referenced by a node that is not hoarded, which the renderers highlight.
+// Since things are hoarded already built, every reference comes from a field.
+//
+// To render the [DependencyGraph], use the [DependencyGraph.DOT] or [DependencyGraph.Mermaid] methods.
+//
+// Example usage:
+//
+//	os.WriteFile("hoard.dot", []byte(Graph(nil).DOT()), 0o644)
+func Graph(h Hoarder) DependencyGraph {
+	h = pickHoarder(h)
+
+	// group the slots of each thing, which share the same hoarding
+	things := make(map[uint64]*graphThing)
+	slots := make(map[graphSlot]uint64)
+
+	for k, v := range h.loadout() {
+		for name, r := range v.records() {
+			if r.item.use() == nil {
+				continue
+			}
+
+			slots[graphSlot{inventoryName: k, name: name}] = r.hoarding
+
+			typ, alias := getItemTypeAndName(name, reflect.TypeOf(r.item.use()))
+
+			t, ok := things[r.hoarding]
+			if !ok {
+				t = &graphThing{
+					node: GraphNode{
+						Type:    typ,
+						Hoarded: true,
+					},
+					inventoryName: k,
+					thing:         r.item.use(),
+				}
+				things[r.hoarding] = t
+			}
+
+			if r.shadowOf != "" {
+				t.inventoryName = r.shadowOf
+			}
+
+			if alias != "" {
+				t.node.Name = alias
+			}
+		}
+	}
+
+	hoarded := make([]*graphThing, 0, len(things))
+	for _, t := range things {
+		t.node.Inventory = getOriginalInventoryName(t.inventoryName)
+		hoarded = append(hoarded, t)
+	}
+
+	slices.SortFunc(hoarded, func(a, b *graphThing) int {
+		return compareGraphNodes(a.node, b.node)
+	})
+
+	ids := make(map[uint64]string, len(hoarded))
+	for i, t := range hoarded {
+		t.node.ID = "n" + strconv.Itoa(i+1)
+	}
+
+	for hoarding, t := range things {
+		ids[hoarding] = t.node.ID
+	}
+
+	g := DependencyGraph{
+		Nodes: make([]GraphNode, 0, len(hoarded)),
+		Edges: make([]GraphEdge, 0),
+	}
+
+	for _, t := range hoarded {
+		g.Nodes = append(g.Nodes, t.node)
+	}
+
+	// the things that are not hoarded are numbered after the hoarded ones
+	missing := make(map[string]string)
+
+	for _, t := range hoarded {
+		for _, f := range graphFields(t.thing) {
+			to := ""
+
+			for _, typeOfThing := range []reflect.Type{f.typeOfValue, f.typeOfField} {
+				if typeOfThing == nil || to != "" {
+					continue
+				}
+
+				for _, inventoryName := range []string{t.inventoryName, defaultInventoryName} {
+					v, _ := h.resolve(typeOfThing, inventoryName, "")
+					if v == nil {
+						continue
+					}
+
+					if hoarding, ok := slots[graphSlot{inventoryName: inventoryName, name: v.getName()}]; ok {
+						to = ids[hoarding]
+						break
+					}
+				}
+			}
+
+			if to == "" {
+				id, ok := missing[f.typeOfField.String()]
+				if !ok {
+					id = "n" + strconv.Itoa(len(g.Nodes)+1)
+					missing[f.typeOfField.String()] = id
+
+					g.Nodes = append(g.Nodes, GraphNode{
+						ID:   id,
+						Type: f.typeOfField.String(),
+					})
+				}
+
+				to = id
+			}
+
+			// a thing referencing itself, e.g. through a linked list, is not a dependency
+			if to == t.node.ID {
+				continue
+			}
+
+			g.Edges = append(g.Edges, GraphEdge{From: t.node.ID, To: to, Field: f.name})
+		}
+	}
+
+	slices.SortFunc(g.Edges, func(a, b GraphEdge) int {
+		return cmp.Or(compareGraphIDs(a.From, b.From), compareGraphIDs(a.To, b.To), cmp.Compare(a.Field, b.Field))
+	})
+
+	return g
+}
+
+// graphField is a struct that describes a field of a hoarded thing that may reference another thing.
+type graphField struct {
+	name string
+
+	// typeOfField is the type of the field.
+	typeOfField reflect.Type
+
+	// typeOfValue is the dynamic type of the field if it is a non-nil interface, or nil otherwise.
+	typeOfValue reflect.Type
+}
+
+// graphFields is a function that returns the fields of the given thing that may reference another thing.
+func graphFields(thing interface{}) []graphField {
+	v := reflect.ValueOf(thing)
+
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := make([]graphField, 0)
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+
+		switch {
+		case f.Type.Kind() == reflect.Pointer && f.Type.Elem().Name() != "":
+			fields = append(fields, graphField{name: f.Name, typeOfField: f.Type})
+		case f.Type.Kind() == reflect.Interface && f.Type.NumMethod() > 0:
+			field := graphField{name: f.Name, typeOfField: f.Type}
+			if !v.Field(i).IsNil() {
+				field.typeOfValue = v.Field(i).Elem().Type()
+			}
+
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// compareGraphNodes is a function that compares the given nodes by inventory, type and name.
+func compareGraphNodes(a, b GraphNode) int {
+	return cmp.Or(cmp.Compare(a.Inventory, b.Inventory), cmp.Compare(a.Type, b.Type), cmp.Compare(a.Name, b.Name))
+}
+
+// compareGraphIDs is a function that compares the given node identifiers by their number.
+func compareGraphIDs(a, b string) int {
+	x, _ := strconv.Atoi(strings.TrimPrefix(a, "n"))
+	y, _ := strconv.Atoi(strings.TrimPrefix(b, "n"))
+
+	return cmp.Compare(x, y)
+}
+
+// label is a method that returns the lines describing the node.
+func (n GraphNode) label() []string {
+	lines := []string{n.Type}
+
+	if n.Name != "" {
+		lines[0] += " " + strconv.Quote(n.Name)
+	}
+
+	if n.Hoarded {
+		lines = append(lines, "inventory "+n.Inventory)
+	} else {
+		lines = append(lines, "not hoarded")
+	}
+
+	return lines
+}
+
+// DOT is a method that renders the [DependencyGraph] in the Graphviz DOT language.
+// The things that are not hoarded are drawn dashed and in red.
+// Example output:
+//
+//	digraph hoard {
+//		rankdir=LR;
+//		node [shape=box];
+//		n1 [label="*api.Server\ninventory default"];
+//		n2 [label="*sql.DB \"primary\"\ninventory default"];
+//		n3 [label="*log.Logger\nnot hoarded", style=dashed, color=red];
+//		n1 -> n2 [label="DB"];
+//		n1 -> n3 [label="Logger"];
+//	}
+func (g DependencyGraph) DOT() string {
+	b := strings.Builder{}
+
+	b.WriteString("digraph hoard {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box];\n")
+
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+	for _, n := range g.Nodes {
+		lines := n.label()
+		for i := range lines {
+			lines[i] = escape.Replace(lines[i])
+		}
+
+		b.WriteString("\t" + n.ID + ` [label="` + strings.Join(lines, `\n`) + `"`)
+
+		if !n.Hoarded {
+			b.WriteString(", style=dashed, color=red")
+		}
+
+		b.WriteString("];\n")
+	}
+
+	for _, e := range g.Edges {
+		b.WriteString("\t" + e.From + " -> " + e.To + ` [label="` + escape.Replace(e.Field) + `"];` + "\n")
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// Mermaid is a method that renders the [DependencyGraph] as a Mermaid flowchart.
+// The things that are not hoarded are drawn dashed and in red.
+// Example output:
+//
+//	flowchart LR
+//		n1["*api.Server<br/>inventory default"]
+//		n2["*sql.DB #quot;primary#quot;<br/>inventory default"]
+//		n3["*log.Logger<br/>not hoarded"]:::missing
+//		n1 -->|DB| n2
+//		n1 -->|Logger| n3
+//		classDef missing stroke:#f00,stroke-dasharray:5 5
+func (g DependencyGraph) Mermaid() string {
+	b := strings.Builder{}
+
+	b.WriteString("flowchart LR\n")
+
+	escape := strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;", "\n", " ")
+
+	for _, n := range g.Nodes {
+		lines := n.label()
+		for i := range lines {
+			lines[i] = escape.Replace(lines[i])
+		}
+
+		b.WriteString("\t" + n.ID + `["` + strings.Join(lines, "<br/>") + `"]`)
+
+		if !n.Hoarded {
+			b.WriteString(":::missing")
+		}
+
+		b.WriteString("\n")
+	}
+
+	for _, e := range g.Edges {
+		b.WriteString("\t" + e.From + " -->|" + escape.Replace(e.Field) + "| " + e.To + "\n")
+	}
+
+	b.WriteString("\tclassDef missing stroke:#f00,stroke-dasharray:5 5\n")
+
+	return b.String()
+}
@@ -0,0 +1,102 @@
+package hoard
+
+import (
+	"github.com/stretchr/testify/require"
+)
+
+type testGraphDB struct{}
+
+type testGraphCache interface {
+	get(key string) string
+}
+
+type testGraphRedis struct{}
+
+func (testGraphRedis) get(key string) string {
+	return key
+}
+
+type testGraphLogger struct{}
+
+type testGraphService struct {
+	DB     *testGraphDB
+	cache  testGraphCache
+	Logger *testGraphLogger
+	Name   string
+	self   *testGraphService
+	any    interface{}
+}
+
+func (s *suiteTest) TestGraph() {
+	h := factory()
+
+	service := &testGraphService{
+		DB:    &testGraphDB{},
+		cache: testGraphRedis{},
+		any:   &testGraphDB{},
+	}
+	service.self = service
+
+	Hoard(
+		HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h),
+		service,
+		RememberAs(&testGraphDB{}, "primary"),
+		UseInventory("cache").Put(RememberAs(testGraphRedis{}, "")),
+	)
+
+	got := Graph(h)
+
+	require.Equal(s.T(), []GraphNode{
+		{ID: "n1", Inventory: "cache", Type: "hoard.testGraphRedis", Hoarded: true},
+		{ID: "n2", Inventory: "default", Type: "*hoard.testGraphDB", Name: "primary", Hoarded: true},
+		{ID: "n3", Inventory: "default", Type: "*hoard.testGraphService", Hoarded: true},
+		{ID: "n4", Type: "*hoard.testGraphLogger"},
+	}, got.Nodes)
+
+	require.Equal(s.T(), []GraphEdge{
+		{From: "n3", To: "n1", Field: "cache"},
+		{From: "n3", To: "n2", Field: "DB"},
+		{From: "n3", To: "n4", Field: "Logger"},
+	}, got.Edges)
+
+	require.Equal(s.T(), `digraph hoard {
+	rankdir=LR;
+	node [shape=box];
+	n1 [label="hoard.testGraphRedis\ninventory cache"];
+	n2 [label="*hoard.testGraphDB \"primary\"\ninventory default"];
+	n3 [label="*hoard.testGraphService\ninventory default"];
+	n4 [label="*hoard.testGraphLogger\nnot hoarded", style=dashed, color=red];
+	n3 -> n1 [label="cache"];
+	n3 -> n2 [label="DB"];
+	n3 -> n4 [label="Logger"];
+}
+`, got.DOT())
+
+	require.Equal(s.T(), `flowchart LR
+	n1["hoard.testGraphRedis<br/>inventory cache"]
+	n2["*hoard.testGraphDB #quot;primary#quot;<br/>inventory default"]
+	n3["*hoard.testGraphService<br/>inventory default"]
+	n4["*hoard.testGraphLogger<br/>not hoarded"]:::missing
+	n3 -->|cache| n1
+	n3 -->|DB| n2
+	n3 -->|Logger| n4
+	classDef missing stroke:#f00,stroke-dasharray:5 5
+`, got.Mermaid())
+}
+
+func (s *suiteTest) TestGraph_empty() {
+	got := Graph(factory())
+
+	require.Empty(s.T(), got.Nodes)
+	require.Empty(s.T(), got.Edges)
+	require.Equal(s.T(), "digraph hoard {\n\trankdir=LR;\n\tnode [shape=box];\n}\n", got.DOT())
+}
+
+func (s *suiteTest) TestGraph_nilThing() {
+	h := Hoard(HoardOptions{}.ShouldReplaceGlobal(false), RememberAs(nil, "x"), &testGraphDB{})
+
+	got := Graph(h)
+
+	require.Equal(s.T(), []GraphNode{{ID: "n1", Inventory: "default", Type: "*hoard.testGraphDB", Hoarded: true}}, got.Nodes)
+	require.Empty(s.T(), got.Edges)
+}
@@ -0,0 +1,266 @@
+package hoard
+
+import (
+	"cmp"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ShouldWalkUpInventories is a method that sets the [walkUpInventories] field in the [equipConfig] struct to the given value.
+// The method returns a new [EquipOptions] with the updated configuration.
+// Typical usage of this method is to organise the inventories as a hierarchy of slash-separated names, such as "payments/eu/primary",
+// and to look the desired thing up in the parent inventories, such as "payments/eu" then "payments", if it cannot be equipped from the specified inventory.
+// Each fallback inventory is walked up the same way, refer to the [EquipOptions.WithFallbackInventories] method.
+// The default [Inventory] is not part of the hierarchy, pass an empty string as the last fallback inventory to end there.
+//
+// Example usage:
+//
+//	EquipWithOption[*sql.DB](EquipOptions{}.WithCustomInventoryName("payments/eu/primary").ShouldWalkUpInventories(true))
+func (h EquipOptions) ShouldWalkUpInventories(walkUpInventories bool) EquipOptions {
+	return append(h, newFuncEquipOptions(func(opt *equipConfig) *equipConfig {
+		opt.walkUpInventories = walkUpInventories
+		return opt
+	}))
+}
+
+// WithInventoryPattern is a method that sets the [inventoryPattern] field in the [equipConfig] struct to the given value.
+// The method returns a new [EquipOptions] with the updated configuration.
+// Typical usage of this method is to enumerate the things of every inventory of a domain with the [EquipAll] function.
+//
+// The pattern is matched against each slash-separated segment of the inventory names:
+// "*" matches a single segment, "**" matches any number of segments, including none,
+// and any other segment is matched with the [path.Match] function, e.g. "eu-*".
+// The default [Inventory] is named "default".
+//
+// Example usage:
+//
+//	EquipAll[*sql.DB](EquipOptions{}.WithInventoryPattern("payments/*"))
+//	EquipAll[*sql.DB](EquipOptions{}.WithInventoryPattern("payments/**"))
+func (h EquipOptions) WithInventoryPattern(inventoryPattern string) EquipOptions {
+	return append(h, newFuncEquipOptions(func(opt *equipConfig) *equipConfig {
+		opt.inventoryPattern = inventoryPattern
+		return opt
+	}))
+}
+
+// EquipAll is a function that returns every thing of type T hoarded in the inventories matching the pattern, sorted by inventory and name.
+// A thing is of type T if its type is T, or implements T if T is an interface.
+// Each thing is returned once per [Inventory] it has been hoarded into, whatever the number of names it is hoarded under,
+// and the copies shadowed into the default [Inventory] from the other ones are skipped.
+// The function returns an empty slice if no thing matches. Unlike the [EquipWithOption] function, it never panics.
+//
+// To specify the pattern, use the [EquipOptions.WithInventoryPattern] method. Without a pattern, only the specified [Inventory] is enumerated.
+// To only return the things of a custom [Item] name, use the [EquipOptions.WithCustomItemName] method.
+// To only return the things whose labels match a selector, use the [EquipOptions.WithSelector] method; an invalid selector matches no thing.
+//
+// To specify a custom [Hoarder] to be used, pass the custom [Hoarder] as an argument when calling the [EquipAll] function.
+//
+// The [EquipAll] function is thread-safe.
+//
+// Example usage:
+//
+//	for _, db := range EquipAll[*sql.DB](EquipOptions{}.WithInventoryPattern("payments/*")) {
+//		db.Close()
+//	}
+func EquipAll[T any](opt EquipOptions, customHoarder ...Hoarder) []T {
+	cfg := defaultEquipConfig
+
+	for _, f := range opt {
+		f.apply(&cfg)
+	}
+
+	typeOfType := reflect.TypeFor[T]()
+
+	hoarder := pickHoarder(customHoarder...)
+
+	things := make([]T, 0)
+	for _, s := range enumerate(hoarder, typeOfType, cfg) {
+		v, _ := hoarder.equipExact(typeOfType, s.inventoryName, s.itemName, s.name)
+
+		// the slot may have changed since it was enumerated
+		if v == nil {
+			continue
+		}
+
+		if thing, ok := v.use().(T); ok {
+			things = append(things, thing)
+		}
+	}
+
+	return things
+}
+
+// enumeratedSlot is a struct that identifies the slot a thing is enumerated from by the [EquipAll] function.
+type enumeratedSlot struct {
+
+	// inventoryName is the internal name of the [Inventory] holding the slot.
+	inventoryName string
+
+	// name is the name of the slot.
+	name string
+
+	// typ is the type of the thing.
+	typ string
+
+	// itemName is the custom [Item] name of the thing, or an empty string if none.
+	itemName string
+
+	// labels holds the labels of the thing, refer to the [Label] function.
+	labels map[string]string
+}
+
+// describe is a method that returns a human-readable description of the thing of the slot.
+func (s enumeratedSlot) describe() string {
+	return describeItem(s.typ, s.itemName, getOriginalInventoryName(s.inventoryName))
+}
+
+// enumerate is a function that returns a slot of each thing of the given type hoarded in the inventories of the given [Hoarder] selected by the given configuration,
+// sorted by inventory and name.
+// The function returns nil if the selector of the configuration is invalid.
+func enumerate(h Hoarder, typeOfThing reflect.Type, cfg equipConfig) []enumeratedSlot {
+	sel, err := parseSelector(cfg.selector)
+	if err != nil {
+		return nil
+	}
+
+	selected := func(inventoryName string) bool {
+		if cfg.inventoryPattern == "" {
+			return inventoryName == getCustomInventoryName(cfg.customInventoryName)
+		}
+
+		return matchInventoryPattern(cfg.inventoryPattern, getOriginalInventoryName(inventoryName))
+	}
+
+	enumerated := make([]enumeratedSlot, 0)
+
+	for k, v := range h.loadout() {
+		if !selected(k) {
+			continue
+		}
+
+		for _, s := range inventorySlots(k, v, typeOfThing, false) {
+			if cfg.customItemName != "" && s.itemName != cfg.customItemName {
+				continue
+			}
+
+			if !sel.matches(s.labels) {
+				continue
+			}
+
+			enumerated = append(enumerated, s)
+		}
+	}
+
+	slices.SortFunc(enumerated, compareEnumeratedSlots)
+
+	return enumerated
+}
+
+// inventorySlots is a function that returns a slot of each thing of the given type hoarded in the given [Inventory], in no particular order.
+// A thing is of the given type if its type is the given one, or implements it if the given type is an interface, the same way as the equip functions do.
+// The copies shadowed into the default [Inventory] from the other ones are only returned if shadows is true.
+func inventorySlots(inventoryName string, inventoryImpl Inventory, typeOfThing reflect.Type, shadows bool) []enumeratedSlot {
+	slots := make(map[uint64]*enumeratedSlot)
+	hoardings := make([]uint64, 0)
+
+	for name, r := range inventoryImpl.records() {
+		if r.item == nil || (r.shadowOf != "" && !shadows) {
+			continue
+		}
+
+		thing := r.item.use()
+		if thing == nil {
+			continue
+		}
+
+		t := reflect.TypeOf(thing)
+		if t != typeOfThing && (typeOfThing.Kind() != reflect.Interface || !t.Implements(typeOfThing)) {
+			continue
+		}
+
+		s, ok := slots[r.hoarding]
+		if !ok {
+			s = &enumeratedSlot{inventoryName: inventoryName, name: name, labels: r.item.getMeta().labels}
+			slots[r.hoarding] = s
+			hoardings = append(hoardings, r.hoarding)
+		}
+
+		// the slot named first is the one the thing is equipped from
+		if name < s.name {
+			s.name = name
+		}
+
+		typ, alias := getItemTypeAndName(name, t)
+		s.typ = typ
+		if alias != "" {
+			s.itemName = alias
+		}
+	}
+
+	enumerated := make([]enumeratedSlot, 0, len(hoardings))
+	for _, hoarding := range hoardings {
+		enumerated = append(enumerated, *slots[hoarding])
+	}
+
+	return enumerated
+}
+
+// compareEnumeratedSlots is a function that compares the given slots by inventory, item name and slot name.
+func compareEnumeratedSlots(a, b enumeratedSlot) int {
+	return cmp.Or(
+		cmp.Compare(getOriginalInventoryName(a.inventoryName), getOriginalInventoryName(b.inventoryName)),
+		cmp.Compare(a.itemName, b.itemName),
+		cmp.Compare(a.name, b.name),
+	)
+}
+
+// matchInventoryPattern is a function that reports whether the given inventory name matches the given pattern, segment by segment.
+// Refer to the [EquipOptions.WithInventoryPattern] method for the syntax of the pattern.
+func matchInventoryPattern(pattern, inventoryName string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(inventoryName, "/"))
+}
+
+// matchSegments is a function that reports whether the given name segments match the given pattern segments.
+func matchSegments(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchSegments(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(name) == 0 {
+		return false
+	}
+
+	if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+		return false
+	}
+
+	return matchSegments(pattern[1:], name[1:])
+}
+
+// parentInventoryNames is a function that returns the names of the parents of the given custom inventory name in the hierarchy, from the nearest one,
+// e.g. "payments/eu" then "payments" for "payments/eu/primary".
+func parentInventoryNames(customInventoryName string) []string {
+	parents := make([]string, 0)
+
+	for {
+		i := strings.LastIndex(customInventoryName, "/")
+		if i <= 0 {
+			return parents
+		}
+
+		customInventoryName = customInventoryName[:i]
+		parents = append(parents, customInventoryName)
+	}
+}
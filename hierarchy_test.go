@@ -0,0 +1,174 @@
+package hoard
+
+import (
+	"github.com/stretchr/testify/require"
+)
+
+type testHierarchyDB struct {
+	Name string
+}
+
+func (db *testHierarchyDB) name() string {
+	return db.Name
+}
+
+type testHierarchyNamer interface {
+	name() string
+}
+
+func (s *suiteTest) TestShouldWalkUpInventories() {
+	opt := HoardOptions{}.ShouldReplaceGlobal(false)
+
+	h := Hoard(opt,
+		"root",
+		UseInventory("payments").Put(RememberAs(1, "")).Put(RememberAs(&testHierarchyDB{Name: "payments"}, "primary")),
+		UseInventory("payments/eu").Put(RememberAs(&testHierarchyDB{Name: "eu"}, "primary")),
+		UseInventory("payments/eu/primary").Put(RememberAs(2.5, "")),
+		UseInventory("shared/cache").Put(RememberAs(true, "")),
+	)
+
+	options := EquipOptions{}.WithCustomInventoryName("payments/eu/primary").ShouldWalkUpInventories(true)
+
+	require.Equal(s.T(), 2.5, EquipWithOption[float64](options, h))
+	require.Equal(s.T(), "eu", EquipWithOption[*testHierarchyDB](options.WithCustomItemName("primary"), h).Name)
+	require.Equal(s.T(), "eu", EquipWithOption[testHierarchyNamer](options, h).name())
+	require.Equal(s.T(), 1, EquipWithOption[int](options, h))
+
+	// the default inventory is not part of the hierarchy
+	require.Panics(s.T(), func() {
+		EquipWithOption[string](options, h)
+	})
+	require.Equal(s.T(), "root", EquipWithOption[string](options.WithFallbackInventories(""), h))
+
+	// each fallback inventory is walked up too
+	require.True(s.T(), EquipWithOption[bool](options.WithFallbackInventories("shared/cache/eu"), h))
+
+	require.Panics(s.T(), func() {
+		EquipWithOption[int](EquipOptions{}.WithCustomInventoryName("payments/eu/primary"), h)
+	})
+	require.Panics(s.T(), func() {
+		EquipWithOption[int](options.ShouldWalkUpInventories(false), h)
+	})
+
+	h.Require(Need[int](options))
+	require.NoError(s.T(), h.Verify())
+}
+
+func (s *suiteTest) TestEquipAll() {
+	opt := HoardOptions{}.ShouldReplaceGlobal(false)
+
+	h := Hoard(opt,
+		&testHierarchyDB{Name: "root"},
+		UseInventory("payments").Put(RememberAs(&testHierarchyDB{Name: "payments"}, "")),
+		UseInventory("payments/eu").Put(RememberAs(&testHierarchyDB{Name: "eu-primary"}, "primary")).Put(RememberAs(&testHierarchyDB{Name: "eu-replica"}, "replica")),
+		UseInventory("payments/eu/archive").Put(RememberAs(&testHierarchyDB{Name: "archive"}, "")),
+		UseInventory("payments/us").Put(RememberAs(&testHierarchyDB{Name: "us"}, "")).Put(RememberAs(1, "")),
+		UseInventory("orders").Put(RememberAs(&testHierarchyDB{Name: "orders"}, "")),
+	)
+
+	names := func(dbs []*testHierarchyDB) []string {
+		names := make([]string, 0, len(dbs))
+		for _, db := range dbs {
+			names = append(names, db.Name)
+		}
+
+		return names
+	}
+
+	tests := []struct {
+		name string
+		opt  EquipOptions
+		want []string
+	}{
+		{
+			name: "should enumerate the default inventory without the shadowed things",
+			opt:  nil,
+			want: []string{"root"},
+		},
+		{
+			name: "should enumerate the specified inventory",
+			opt:  EquipOptions{}.WithCustomInventoryName("payments/eu"),
+			want: []string{"eu-primary", "eu-replica"},
+		},
+		{
+			name: "should enumerate the inventories matching a single segment",
+			opt:  EquipOptions{}.WithInventoryPattern("payments/*"),
+			want: []string{"eu-primary", "eu-replica", "us"},
+		},
+		{
+			name: "should enumerate the inventories matching any number of segments",
+			opt:  EquipOptions{}.WithInventoryPattern("payments/**"),
+			want: []string{"payments", "eu-primary", "eu-replica", "archive", "us"},
+		},
+		{
+			name: "should enumerate every inventory",
+			opt:  EquipOptions{}.WithInventoryPattern("**"),
+			want: []string{"root", "orders", "payments", "eu-primary", "eu-replica", "archive", "us"},
+		},
+		{
+			name: "should only enumerate the things of the custom item name",
+			opt:  EquipOptions{}.WithInventoryPattern("**").WithCustomItemName("replica"),
+			want: []string{"eu-replica"},
+		},
+		{
+			name: "should enumerate nothing if no inventory matches",
+			opt:  EquipOptions{}.WithInventoryPattern("inventory/*"),
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			require.Equal(s.T(), tt.want, names(EquipAll[*testHierarchyDB](tt.opt, h)))
+		})
+	}
+
+	namers := EquipAll[testHierarchyNamer](EquipOptions{}.WithInventoryPattern("payments/eu"), h)
+	require.Len(s.T(), namers, 2)
+	require.Equal(s.T(), "eu-primary", namers[0].name())
+
+	require.Equal(s.T(), []int{1}, EquipAll[int](EquipOptions{}.WithInventoryPattern("**"), h))
+	require.Empty(s.T(), EquipAll[string](EquipOptions{}.WithInventoryPattern("**"), h))
+
+	// the enumerated things are reported as equipped
+	equips := func() uint64 {
+		var equips uint64
+		for _, e := range h.Stats().Equips {
+			if e.Inventory == "payments/us" {
+				equips += e.Equips
+			}
+		}
+
+		return equips
+	}
+
+	before := equips()
+	EquipAll[*testHierarchyDB](EquipOptions{}.WithCustomInventoryName("payments/us"), h)
+	require.Equal(s.T(), before+1, equips())
+}
+
+func (s *suiteTest) TestMatchInventoryPattern() {
+	tests := []struct {
+		pattern       string
+		inventoryName string
+		want          bool
+	}{
+		{pattern: "payments", inventoryName: "payments", want: true},
+		{pattern: "payments", inventoryName: "payments/eu", want: false},
+		{pattern: "payments/*", inventoryName: "payments/eu", want: true},
+		{pattern: "payments/*", inventoryName: "payments", want: false},
+		{pattern: "payments/*", inventoryName: "payments/eu/primary", want: false},
+		{pattern: "payments/**", inventoryName: "payments", want: true},
+		{pattern: "payments/**", inventoryName: "payments/eu/primary", want: true},
+		{pattern: "**/primary", inventoryName: "payments/eu/primary", want: true},
+		{pattern: "payments/eu-*", inventoryName: "payments/eu-west", want: true},
+		{pattern: "*/eu/*", inventoryName: "payments/eu/primary", want: true},
+		{pattern: "payments/[", inventoryName: "payments/eu", want: false},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.pattern+" "+tt.inventoryName, func() {
+			require.Equal(s.T(), tt.want, matchInventoryPattern(tt.pattern, tt.inventoryName))
+		})
+	}
+}
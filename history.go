@@ -0,0 +1,219 @@
+package hoard
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// historyLimit is the maximum number of records kept for each slot of an [Inventory], including the current one.
+	historyLimit = 16
+)
+
+var (
+	// ErrNotEnoughHistory is returned when rolling back more steps than the history of a slot holds.
+	ErrNotEnoughHistory = errors.New("hoard: not enough history")
+)
+
+// origin is a struct that describes where and when a slot of an [Inventory] was changed.
+// This struct is used internally and should not be used directly.
+type origin struct {
+
+	// pc is the program counter of the code that changed the slot.
+	// It is only resolved into a file and line by the caller method, when needed, to keep hoarding cheap.
+	pc uintptr
+
+	// at is the time the slot was changed.
+	at time.Time
+
+	// shadowOf is the name of the custom [Inventory] the [Item] has been copied from into the default [Inventory], if any.
+	shadowOf string
+
+	// hoarding identifies the hoarding of a single thing, and is shared by every slot the thing is hoarded under.
+	hoarding uint64
+}
+
+var (
+	// lastHoarding is the identifier of the latest hoarding of a thing.
+	lastHoarding atomic.Uint64
+)
+
+// newOrigin is a function that returns a new [origin] describing the caller of the function calling it.
+// The skip parameter is the number of additional stack frames to skip.
+func newOrigin(skip int) origin {
+	o := origin{
+		at:       time.Now(),
+		hoarding: lastHoarding.Add(1),
+	}
+
+	var pc [1]uintptr
+	if runtime.Callers(skip+3, pc[:]) == 1 {
+		o.pc = pc[0]
+	}
+
+	return o
+}
+
+// caller is a method that returns the file and line, formatted as "file:line", of the code that changed the slot.
+// The method returns an empty string if the code is unknown.
+func (o origin) caller() string {
+	if o.pc == 0 {
+		return ""
+	}
+
+	frame, _ := runtime.CallersFrames([]uintptr{o.pc}).Next()
+	if frame.File == "" {
+		return ""
+	}
+
+	return frame.File + ":" + strconv.Itoa(frame.Line)
+}
+
+// renewed is a method that returns a copy of the origin identifying a new hoarding of a thing.
+func (o origin) renewed() origin {
+	o.hoarding = lastHoarding.Add(1)
+
+	return o
+}
+
+// shadowing is a method that returns a copy of the origin describing an [Item] copied from the given custom [Inventory] into the default [Inventory].
+func (o origin) shadowing(inventoryName string) origin {
+	o.shadowOf = inventoryName
+
+	return o
+}
+
+// record is a struct that describes a single change of a slot of an [Inventory].
+// This struct is used internally and should not be used directly.
+type record struct {
+
+	// item is the [Item] put into the slot, or nil if the slot was emptied.
+	item Item
+
+	// version is the version of the slot after the change.
+	version uint64
+
+	origin
+}
+
+// appendRecord is a function that appends the given record to the given records, keeping at most [historyLimit] records.
+func appendRecord(records []record, r record) []record {
+	records = append(records, r)
+
+	if len(records) > historyLimit {
+		records = records[len(records)-historyLimit:]
+	}
+
+	return records
+}
+
+// HistoryRecord is a struct that describes a single change of the slot a thing of type T is hoarded in.
+// The struct is returned by the [History] function.
+type HistoryRecord[T any] struct {
+
+	// Thing is the thing put into the slot, or the zero value of T if the thing was discarded.
+	Thing T
+
+	// Discarded is a boolean that reports whether the thing was discarded from the slot.
+	Discarded bool
+
+	// Version is the version of the slot after the change.
+	Version uint64
+
+	// Caller is the file and line, formatted as "file:line", of the code that changed the slot.
+	Caller string
+
+	// At is the time the slot was changed.
+	At time.Time
+}
+
+// History is a function that returns the latest changes of the slot the requested thing is hoarded in, from the oldest to the current one.
+// The slot is the one the thing is resolved from the same way as the [EquipWithOption] function does,
+// or the slot of the exact [Item] name if the thing is not hoarded anymore.
+// At most 16 changes are kept for each slot, including the ones merged from another [Hoarder].
+//
+// To specify custom [Item] name or custom [Inventory] name, use the [EquipOptions] when calling the [History] function.
+//
+// To specify a custom [Hoarder] to be used, pass the custom [Hoarder] as an argument when calling the [History] function.
+//
+// The [History] function is thread-safe.
+//
+// Example usage:
+//
+//	for _, r := range History[*Client](nil) {
+//		fmt.Println(r.Version, r.Caller, r.At)
+//	}
+func History[T any](opt EquipOptions, customHoarder ...Hoarder) []HistoryRecord[T] {
+	cfg := defaultEquipConfig
+
+	for _, f := range opt {
+		f.apply(&cfg)
+	}
+
+	hoarder := pickHoarder(customHoarder...)
+
+	records := hoarder.recall(reflect.TypeFor[T](), getCustomInventoryName(cfg.customInventoryName), cfg.customItemName)
+
+	history := make([]HistoryRecord[T], 0, len(records))
+	for _, r := range records {
+		hr := HistoryRecord[T]{
+			Discarded: r.item == nil,
+			Version:   r.version,
+			Caller:    r.caller(),
+			At:        r.at,
+		}
+
+		if r.item != nil {
+			thing, ok := r.item.use().(T)
+
+			// an alias may be shared by things of different types
+			if !ok {
+				continue
+			}
+
+			hr.Thing = thing
+		}
+
+		history = append(history, hr)
+	}
+
+	return history
+}
+
+// Rollback is a function that restores the slot the requested thing is hoarded in to the state it had the given number of changes ago.
+// The slot is the same one the [History] function returns the changes of.
+// Restoring a slot is itself a change of the slot, hence rolling back 1 step twice restores the original state.
+// The function returns [ErrNotEnoughHistory] if the slot has fewer changes than the given number of steps.
+//
+// To specify custom [Item] name or custom [Inventory] name, use the [EquipOptions] when calling the [Rollback] function.
+//
+// To specify a custom [Hoarder] to be used, pass the custom [Hoarder] as an argument when calling the [Rollback] function.
+// Passing a [Tx] restores the slot only once the transaction succeeds.
+//
+// The [Rollback] function is thread-safe.
+//
+// Example usage:
+//
+//	err := Rollback[*Client](nil, 1)
+func Rollback[T any](opt EquipOptions, steps int, customHoarder ...Hoarder) error {
+	cfg := defaultEquipConfig
+
+	for _, f := range opt {
+		f.apply(&cfg)
+	}
+
+	hoarder := pickHoarder(customHoarder...)
+
+	return hoarder.apply(operation{
+		kind:          operationRollback,
+		typeOfThing:   reflect.TypeFor[T](),
+		inventoryName: getCustomInventoryName(cfg.customInventoryName),
+		itemName:      cfg.customItemName,
+		steps:         steps,
+		origin:        newOrigin(0),
+	})
+}
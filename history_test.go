@@ -0,0 +1,235 @@
+package hoard
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/stretchr/testify/require"
+)
+
+func (s *suiteTest) TestHistory() {
+	tests := []struct {
+		name          string
+		givenChanges  func(h Hoarder)
+		givenOption   EquipOptions
+		wantThings    []string
+		wantDiscarded []bool
+	}{
+		{
+			name: "should return every change of the slot from the oldest to the current one",
+			givenChanges: func(h Hoarder) {
+				Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), "test")
+				Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), "test2")
+				Discard[string](nil, h)
+				Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), "test3")
+			},
+			givenOption:   nil,
+			wantThings:    []string{"test", "test2", "", "test3"},
+			wantDiscarded: []bool{false, false, true, false},
+		},
+		{
+			name: "should return the changes of the slot in a custom inventory",
+			givenChanges: func(h Hoarder) {
+				Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), UseInventory("test").Put(RememberAs("test", "test")))
+				Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), UseInventory("test").Put(RememberAs("test2", "test")))
+			},
+			givenOption:   EquipOptions{}.WithCustomInventoryName("test").WithCustomItemName("test"),
+			wantThings:    []string{"test", "test2"},
+			wantDiscarded: []bool{false, false},
+		},
+		{
+			name: "should keep at most the limit of changes",
+			givenChanges: func(h Hoarder) {
+				for i := 0; i < historyLimit+4; i++ {
+					Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), strconv.Itoa(i))
+				}
+			},
+			givenOption: nil,
+			wantThings: func() []string {
+				things := []string{}
+				for i := 4; i < historyLimit+4; i++ {
+					things = append(things, strconv.Itoa(i))
+				}
+
+				return things
+			}(),
+			wantDiscarded: make([]bool, historyLimit),
+		},
+		{
+			name:          "should return nothing if the inventory is not mapped",
+			givenChanges:  func(h Hoarder) {},
+			givenOption:   EquipOptions{}.WithCustomInventoryName("test"),
+			wantThings:    []string{},
+			wantDiscarded: []bool{},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			h := factory()
+
+			tt.givenChanges(h)
+
+			got := History[string](tt.givenOption, h)
+
+			gotThings := []string{}
+			gotDiscarded := []bool{}
+			for i, r := range got {
+				gotThings = append(gotThings, r.Thing)
+				gotDiscarded = append(gotDiscarded, r.Discarded)
+
+				require.True(s.T(), strings.Contains(r.Caller, "history_test.go:"), r.Caller)
+				require.False(s.T(), r.At.IsZero())
+
+				if i > 0 {
+					require.Greater(s.T(), r.Version, got[i-1].Version)
+				}
+			}
+
+			require.Equal(s.T(), tt.wantThings, gotThings)
+			require.Equal(s.T(), tt.wantDiscarded, gotDiscarded)
+		})
+	}
+}
+
+func (s *suiteTest) TestHistory_survivesMerge() {
+	h := factory()
+	Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), "test")
+
+	other := factory()
+	Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(other), "test2")
+
+	h.merge(other)
+
+	got := History[string](nil, h)
+
+	require.Len(s.T(), got, 2)
+	require.Equal(s.T(), "test", got[0].Thing)
+	require.Equal(s.T(), "test2", got[1].Thing)
+	require.Equal(s.T(), History[string](nil, other)[0].Caller, got[1].Caller)
+}
+
+func (s *suiteTest) TestHistory_mergeAgain() {
+	recorder := &auditRecorder{}
+
+	h := factory()
+	Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h).WithAuditSink(recorder), "test")
+
+	other := factory()
+	Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(other), "test2")
+
+	h.merge(other)
+
+	_, version := EquipVersioned[string](nil, h)
+	events := len(recorder.got())
+	registrations := h.Stats().Registrations
+
+	// merging the same things again changes no slot
+	h.merge(other)
+	h.merge(other)
+
+	require.Len(s.T(), History[string](nil, h), 2)
+	require.Len(s.T(), recorder.got(), events)
+	require.Equal(s.T(), registrations, h.Stats().Registrations)
+	require.True(s.T(), CompareAndSwap(nil, version, "test3", h))
+
+	require.NoError(s.T(), Rollback[string](nil, 1, h))
+	require.Equal(s.T(), "test2", EquipDefault[string](h))
+}
+
+func (s *suiteTest) TestRollback() {
+	tests := []struct {
+		name       string
+		givenSteps int
+		wantErr    error
+		want       interface{}
+	}{
+		{
+			name:       "should restore the previous thing",
+			givenSteps: 1,
+			wantErr:    nil,
+			want:       "test3",
+		},
+		{
+			name:       "should restore a discarded slot",
+			givenSteps: 2,
+			wantErr:    nil,
+			want:       nil,
+		},
+		{
+			name:       "should restore an older thing",
+			givenSteps: 4,
+			wantErr:    nil,
+			want:       "test",
+		},
+		{
+			name:       "should fail if the history is not long enough",
+			givenSteps: 5,
+			wantErr:    ErrNotEnoughHistory,
+			want:       "test4",
+		},
+		{
+			name:       "should fail if the number of steps is not positive",
+			givenSteps: 0,
+			wantErr:    ErrNotEnoughHistory,
+			want:       "test4",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			h := factory()
+			for _, thing := range []string{"test", "test2", "", "test3", "test4"} {
+				if thing == "" {
+					Discard[string](nil, h)
+					continue
+				}
+
+				Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), thing)
+			}
+
+			err := Rollback[string](nil, tt.givenSteps, h)
+
+			require.ErrorIs(s.T(), err, tt.wantErr)
+			require.Equal(s.T(), tt.want, h.get(reflect.TypeFor[string](), defaultInventoryName, ""))
+		})
+	}
+}
+
+func (s *suiteTest) TestRollback_twice() {
+	h := factory()
+	Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), "test")
+	Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), "test2")
+
+	require.NoError(s.T(), Rollback[string](nil, 1, h))
+	require.Equal(s.T(), "test", EquipDefault[string](h))
+
+	require.NoError(s.T(), Rollback[string](nil, 1, h))
+	require.Equal(s.T(), "test2", EquipDefault[string](h))
+
+	got := History[string](nil, h)
+
+	require.True(s.T(), strings.Contains(got[len(got)-1].Caller, "history_test.go:"), got[len(got)-1].Caller)
+}
+
+func (s *suiteTest) TestRollback_transaction() {
+	h := factory()
+	Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), "test", 1)
+	Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), "test2", 2)
+
+	err := h.Update(func(tx *Tx) error {
+		if err := Rollback[string](nil, 1, tx); err != nil {
+			return err
+		}
+
+		require.Equal(s.T(), "test", EquipDefault[string](tx))
+		require.Equal(s.T(), "test2", EquipDefault[string](h))
+
+		return Rollback[int](nil, 1, tx)
+	})
+
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "test", EquipDefault[string](h))
+	require.Equal(s.T(), 1, EquipDefault[int](h))
+}
@@ -1,9 +1,13 @@
 package hoard
 
 import (
+	"context"
+	"maps"
 	"reflect"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 )
 
 const (
@@ -22,6 +26,23 @@ type hoardConfig struct {
 
 	// customHoarder is a custom hoarder that can be used to be merged and returned when calling the [Hoard] function.
 	customHoarder Hoarder
+
+	// auditSink is a sink that receives an [AuditEvent] for every change of the hoarders things are hoarded into.
+	auditSink AuditSink
+
+	// hooks are the [Hooks] called on every hoard, replacement, equip and merge of the hoarders things are hoarded into.
+	hooks Hooks
+
+	// shouldTrackUsage is a boolean that determines whether the hoarders things are hoarded into record which things have been equipped.
+	// It is only applied if hasTrackUsage is true, hence by default, usage tracking of the hoarders is left as is.
+	shouldTrackUsage bool
+
+	// hasTrackUsage is a boolean that reports whether the shouldTrackUsage field has been set.
+	hasTrackUsage bool
+
+	// shadowMode is the [ShadowMode] of the inventories hoarded that do not specify their own.
+	// By default, it is set to [ShadowIfAbsent].
+	shadowMode ShadowMode
 }
 
 var (
@@ -29,6 +50,7 @@ var (
 	// Override the default configuration by specifying the desired configuration in the [HoardOptions] each time when calling the [Hoard] function.
 	defaultHoardConfig = hoardConfig{
 		shouldReplaceGlobal: true,
+		shadowMode:          ShadowIfAbsent,
 	}
 )
 
@@ -90,6 +112,52 @@ func (h HoardOptions) WithCustomHoarder(customHoarder Hoarder) HoardOptions {
 	}))
 }
 
+// WithAuditSink is a method that sets the [auditSink] field in the [hoardConfig] struct to the given value.
+// The method returns a new [HoardOptions] with the updated configuration.
+// Typical usage of this method is to be notified of every registration and override, e.g. to log silent overrides.
+// The sink is set on the hoarders the things are hoarded into, i.e. the global hoarder unless its replacement is disabled and the custom hoarder if any,
+// or the returned hoarder if there is neither, and receives an [AuditEvent] for every change of these hoarders from then on,
+// including the changes made by the same call to the [Hoard] function.
+// Example usage:
+//
+//	Hoard(HoardOptions{}.WithAuditSink(AuditFunc(func(e AuditEvent) { log.Println(e) })))
+func (h HoardOptions) WithAuditSink(auditSink AuditSink) HoardOptions {
+	return append(h, newFuncHoardOptions(func(opt *hoardConfig) *hoardConfig {
+		opt.auditSink = auditSink
+		return opt
+	}))
+}
+
+// WithHooks is a method that sets the [hooks] field in the [hoardConfig] struct to the given value.
+// The method returns a new [HoardOptions] with the updated configuration.
+// Typical usage of this method is to log or trace what the hoarders do, e.g. with the [SlogHooks] or [SpanHooks] functions.
+// The hooks are set on the same hoarders as the ones of the [HoardOptions.WithAuditSink] method, replacing their previous hooks if any.
+// To set multiple hooks, combine them with the [JoinHooks] function.
+// Example usage:
+//
+//	Hoard(HoardOptions{}.WithHooks(SlogHooks(slog.Default())))
+func (h HoardOptions) WithHooks(hooks Hooks) HoardOptions {
+	return append(h, newFuncHoardOptions(func(opt *hoardConfig) *hoardConfig {
+		opt.hooks = hooks
+		return opt
+	}))
+}
+
+// ShouldTrackUsage is a method that sets the [shouldTrackUsage] field in the [hoardConfig] struct to the given value.
+// The method returns a new [HoardOptions] with the updated configuration.
+// Typical usage of this method is to find the things hoarded by the bootstrap code but never equipped, through the [Hoarder.Unused] method or the [ReportUsage] function.
+// Usage tracking is enabled or disabled on the same hoarders as the ones of the [HoardOptions.WithAuditSink] method, and only if this option is given.
+// Example usage:
+//
+//	Hoard(HoardOptions{}.ShouldTrackUsage(true), things...)
+func (h HoardOptions) ShouldTrackUsage(shouldTrackUsage bool) HoardOptions {
+	return append(h, newFuncHoardOptions(func(opt *hoardConfig) *hoardConfig {
+		opt.shouldTrackUsage = shouldTrackUsage
+		opt.hasTrackUsage = true
+		return opt
+	}))
+}
+
 // equipConfig is a struct that holds the configuration to be used when calling the [EquipWithOption] function.
 // This struct is used internally and should not be used directly.
 // To specify the desired configuration, use the [EquipOptions] type when calling the [EquipWithOption] function instead.
@@ -100,6 +168,18 @@ type equipConfig struct {
 
 	// customItemName is a custom [Item] name that can be used to get the desired thing from the specified inventory.
 	customItemName string
+
+	// fallbackInventoryNames holds the custom inventory names the desired thing is looked up in, in order, if not found in the specified inventory.
+	fallbackInventoryNames []string
+
+	// walkUpInventories reports whether the desired thing is looked up in the parents of each inventory in the hierarchy of slash-separated names.
+	walkUpInventories bool
+
+	// inventoryPattern is the pattern the names of the inventories enumerated by the [EquipAll] function must match.
+	inventoryPattern string
+
+	// selector is the selector the labels of the desired thing must match.
+	selector string
 }
 
 var (
@@ -169,6 +249,25 @@ type Hoarder interface {
 	// This method is thread-safe.
 	get(typeOfThing reflect.Type, inventoryName, itemName string) interface{}
 
+	// resolve is a method that returns the [Item] holding the requested thing from the specified inventory, along with the version of its slot.
+	// The method returns the [Item] if found. Otherwise, it returns nil.
+	// This method is used internally and should not be used directly.
+	// This method is thread-safe.
+	resolve(typeOfThing reflect.Type, inventoryName, itemName string) (Item, uint64)
+
+	// equip is a method that resolves the requested thing the same way as the resolve method does, reporting the lookup to the [Hooks] of the hoarder.
+	// The method is used for the lookups requested by the user, while the resolve method is used for the internal ones.
+	// This method is used internally and should not be used directly.
+	// This method is thread-safe.
+	equip(typeOfThing reflect.Type, inventoryName, itemName string) (Item, uint64)
+
+	// equipExact is a method that returns the [Item] hoarded under the given exact name in the specified inventory, along with the version of its slot,
+	// reporting the lookup to the [Hooks] of the hoarder the same way as the equip method does.
+	// Unlike the equip method, the given name is used as is, without falling back to the alias or to the implementations of an interface.
+	// This method is used internally and should not be used directly.
+	// This method is thread-safe.
+	equipExact(typeOfThing reflect.Type, inventoryName, itemName, thingName string) (Item, uint64)
+
 	// loadout is a method that returns the inventory map.
 	// The method returns the inventory map.
 	// This method is used internally and should not be used directly.
@@ -176,9 +275,103 @@ type Hoarder interface {
 	loadout() func(func(string, Inventory) bool)
 
 	// merge is a method that merges the given hoarder with the current hoarder.
+	// The inventories of the given hoarder are copied, never shared, and the given hoarder is never locked while the current hoarder is.
 	// This method is used internally and should not be used directly.
 	// This method is thread-safe.
 	merge(hoarder Hoarder)
+
+	// observe is a method that registers the given function to be called whenever the specified inventory changes.
+	// The method returns a function that unregisters the given function.
+	// The given function is called after the change is visible and without holding any lock of the hoarder.
+	// This method is used internally and should not be used directly.
+	// This method is thread-safe.
+	observe(inventoryName string, notify func()) func()
+
+	// discard is a method that removes the requested thing from the specified inventory.
+	// Both the exact [Item] name and its alias are removed, the latter only if it holds a thing of the requested type.
+	// This method is used internally and should not be used directly.
+	// This method is thread-safe.
+	discard(typeOfThing reflect.Type, inventoryName, itemName string, o origin)
+
+	// recall is a method that returns the latest records of the slot the requested thing is hoarded in, from the oldest to the current one.
+	// The slot is the one the thing is resolved from, or the slot of the exact [Item] name if the thing is not hoarded.
+	// This method is used internally and should not be used directly.
+	// This method is thread-safe.
+	recall(typeOfThing reflect.Type, inventoryName, itemName string) []record
+
+	// apply is a method that applies all the given operations at once.
+	// No reader observes the hoarder with only some of the operations applied.
+	// The method returns [ErrVersionMismatch] or [ErrNotEnoughHistory] without applying any operation if any operation cannot be applied.
+	// This method is used internally and should not be used directly.
+	// This method is thread-safe.
+	apply(operations ...operation) error
+
+	// setAuditSink is a method that sets the sink receiving an [AuditEvent] for every change of the hoarder.
+	// This method is used internally and should not be used directly.
+	// This method is thread-safe.
+	setAuditSink(auditSink AuditSink)
+
+	// setHooks is a method that sets the [Hooks] called on every hoard, replacement, equip and merge of the hoarder.
+	// This method is used internally and should not be used directly.
+	// This method is thread-safe.
+	setHooks(hooks Hooks)
+
+	// setTrackUsage is a method that enables or disables recording which things have been equipped.
+	// This method is used internally and should not be used directly.
+	// This method is thread-safe.
+	setTrackUsage(trackUsage bool)
+
+	// clone is a method that returns a copy of the hoarder sharing no inventory with it, along with its requirements.
+	// This method is used internally and should not be used directly.
+	// This method is thread-safe.
+	clone() Hoarder
+
+	// Stats is a method that returns a snapshot of the usage statistics of the hoarder.
+	// The statistics count every lookup requested by the user, e.g. through the [EquipWithOption] function, and every change of the hoarder.
+	// A [Tx] counts the lookups and changes made through it on its own, and they are never added to the hoarder it was started on.
+	// Refer to the [Stats] type for more details.
+	// This method is thread-safe.
+	Stats() Stats
+
+	// Unused is a method that returns every thing currently hoarded that has not been equipped since usage tracking was enabled.
+	// A thing counts as equipped once it has been equipped under any of its names or from any [Inventory] it is hoarded in, including the default one it is shadowed into.
+	// The method returns nil if usage tracking is disabled, refer to the [HoardOptions.ShouldTrackUsage] method.
+	// This method is thread-safe.
+	Unused() []UnusedItem
+
+	// Require is a method that adds the given requirements to the ones verified by the Verify method.
+	// Typical usage of this method is to declare, next to the code equipping them, the things the application needs.
+	// This method is thread-safe.
+	Require(requirements ...Requirement)
+
+	// Verify is a method that checks all the requirements added by the Require method at once.
+	// The method returns nil if every required thing can be equipped,
+	// or a single error joining an error wrapping [ErrRequirementNotMet] for each required thing that cannot.
+	// Typical usage of this method is to fail fast at boot once the wiring is done, instead of on the first request equipping a missing thing.
+	// This method is thread-safe.
+	Verify() error
+
+	// Update is a method that runs the given function within a transaction on the hoarder.
+	// Everything put or discarded through the given [Tx] becomes visible at once if the function returns nil,
+	// and is discarded altogether if the function returns an error, which is then returned as is.
+	// Refer to the [Tx] type for more details.
+	// This method is thread-safe.
+	Update(fn func(tx *Tx) error) error
+
+	// Clone is a method that returns an independent copy of the hoarder: new inventories and slots holding the same things, along with the requirements of the hoarder.
+	// Changes made to either hoarder afterwards are never visible to the other one.
+	// The copy starts without the audit sink, hooks, statistics, usage tracking and watchers of the hoarder.
+	// Typical usage of this method is to start a test or a sandbox from the production wiring, then diverge freely.
+	// This method is thread-safe.
+	Clone() Hoarder
+
+	// Snapshot is a method that returns a read-only copy of the hoarder at this point in time.
+	// Every lookup made through the snapshot observes the same things, whatever happens to the hoarder since.
+	// Changing the snapshot, e.g. by passing it to [Hoard], [Discard] or its Require method, panics with [ErrReadOnly],
+	// while the [CompareAndSwap], [Rollback] and [Update] functions fail with [ErrReadOnly].
+	// Like a copy returned by the Clone method, the snapshot has its own statistics and no audit sink or hooks.
+	// This method is thread-safe.
+	Snapshot() Hoarder
 }
 
 var (
@@ -198,6 +391,36 @@ type hoarder struct {
 	inventoryMap map[string]Inventory
 
 	mu sync.RWMutex
+
+	// observers is a set that holds the functions to be called whenever an inventory changes.
+	observers map[*observer]struct{}
+
+	observersMu sync.Mutex
+
+	// auditSink is the sink receiving an [AuditEvent] for every change of the hoarder.
+	auditSink AuditSink
+
+	// hooks are the [Hooks] called on every hoard, replacement, equip and merge of the hoarder.
+	hooks Hooks
+
+	// counters counts the lookups and registrations of the hoarder.
+	counters counters
+
+	// trackUsage is a boolean that determines whether the hoarder records which things have been equipped.
+	trackUsage bool
+
+	// equipped is a set that holds the hoarding identifier of every thing equipped while usage is tracked.
+	equipped sync.Map
+
+	// requirements holds the requirements verified by the Verify method.
+	requirements []Requirement
+}
+
+// observer is a struct that holds a function to be called whenever the observed inventory changes.
+// This struct is used internally and should not be used directly.
+type observer struct {
+	inventoryName string
+	notify        func()
 }
 
 // Hoard is a function that creates a new [Hoarder] with the given things and options.
@@ -206,6 +429,7 @@ type hoarder struct {
 // By default, registering things with the [Hoard] function will replace existing things of the same type and alias inside the global hoarder.
 // To disable global [Hoarder] replacement, use the [HoardOptions] to specify the desired configuration.
 // By default, the global [Hoarder] is the only one being merged with the new [Hoarder] created by the [Hoard] function.
+// The first call replacing the global [Hoarder] returns the global [Hoarder] itself.
 // You can specify a custom [Hoarder] to be merged with the new [Hoarder] by using the [HoardOptions] to specify the desired configuration.
 //
 // Multiple things can be registered at once by providing a list of things.
@@ -232,15 +456,56 @@ func Hoard(opt HoardOptions, things ...interface{}) Hoarder {
 		f.apply(&cfg)
 	}
 
-	h := factory(things...)
+	h := factoryWithOrigin(newOrigin(0), cfg.shadowMode, things...)
+
+	configure := func(h Hoarder) {
+		if cfg.auditSink != nil {
+			h.setAuditSink(cfg.auditSink)
+		}
+
+		if cfg.hooks != nil {
+			h.setHooks(cfg.hooks)
+		}
+
+		if cfg.hasTrackUsage {
+			h.setTrackUsage(cfg.shouldTrackUsage)
+		}
+	}
+
+	// nothing else reports the things of a standalone hoarder, they are merged into a configured one the same way as into the global one
+	if !cfg.shouldReplaceGlobal && cfg.customHoarder == nil && (cfg.auditSink != nil || cfg.hooks != nil) {
+		standalone := factory()
+
+		configure(standalone)
+
+		standalone.merge(h)
+
+		return standalone
+	}
+
+	configure(h)
 
 	if cfg.shouldReplaceGlobal {
-		initGlobalHoarder(h)
+		// the first call initializes the global hoarder and returns it,
+		// the things being merged into it the same way as the following calls so that they are reported
+		created := initGlobalHoarderWith(func() Hoarder {
+			return factory()
+		})
 
-		globalHoarder.merge(h)
+		global := globalFactory()
+
+		configure(global)
+
+		global.merge(h)
+
+		if created {
+			h = global
+		}
 	}
 
 	if cfg.customHoarder != nil {
+		configure(cfg.customHoarder)
+
 		cfg.customHoarder.merge(h)
 		return cfg.customHoarder
 	}
@@ -265,12 +530,28 @@ func RememberAs(thing interface{}, name string) Item {
 // UseInventory is a function that creates a new inventory with the given name.
 // The function returns a new [Inventory] with the given name.
 // The name is used to identify the inventory when registering items with the [Hoard] function.
+//
+// To declare the inventories the new inventory inherits from, use the [InheritsFrom] option.
+//
 // Example usage:
 //
 //	UseInventory("customInventory").Put(RememberAs(42, "customName")).Put(RememberAs(42, ""))
-func UseInventory(name string) Inventory {
+//	UseInventory("staging", InheritsFrom("prod")).Put(RememberAs(42, "customName"))
+func UseInventory(name string, opts ...InventoryOption) Inventory {
+	cfg := inventoryConfig{}
+
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+
 	name = getCustomInventoryName(name)
-	inventoryImpl := newInventory(name)
+	inventoryImpl := newInventoryWithHistory(name)
+
+	if cfg.parents != nil {
+		inventoryImpl.inheritFrom(cfg.parents)
+	}
+
+	inventoryImpl.shadowWith(cfg.shadowMode)
 
 	return inventoryImpl
 }
@@ -286,6 +567,10 @@ func EquipDefault[T any](customHoarder ...Hoarder) T {
 // The function returns the requested thing if found. Otherwise, it panics.
 //
 // To specify custom [Item] name or custom [Inventory] name, use the [EquipOptions] when calling the [EquipWithOption] function.
+// The thing is resolved from the specified [Inventory], then from the inventories it inherits from, refer to the [InheritsFrom] function,
+// then from the fallback inventories, refer to the [EquipOptions.WithFallbackInventories] method.
+// To get a default thing instead of a panic when the thing is not hoarded, use the [EquipOr] function.
+// To equip the thing by its labels, use the [EquipOptions.WithSelector] method.
 //
 // To specify a custom [Hoarder] to be used, pass the custom [Hoarder] as an argument when calling the [EquipWithOption] function.
 //
@@ -304,58 +589,300 @@ func EquipWithOption[T any](opt EquipOptions, customHoarder ...Hoarder) T {
 		f.apply(&cfg)
 	}
 
-	customInventoryName := cfg.customInventoryName
-	customItemName := cfg.customItemName
+	typeOfType := reflect.TypeFor[T]()
+
+	hoarder := pickHoarder(customHoarder...)
+
+	if cfg.selector != "" {
+		v, err := equipSelected(hoarder, typeOfType, cfg.inventoryNames(), cfg.customItemName, cfg.selector)
+		if err != nil {
+			panic(err)
+		}
+
+		return v.use().(T)
+	}
+
+	v, _ := equipAcross(hoarder, typeOfType, cfg.inventoryNames(), cfg.customItemName)
+
+	var thing interface{}
+
+	if v != nil {
+		thing = v.use()
+	}
+
+	return thing.(T)
+}
+
+// EquipWait is a function that returns the requested thing from the specified [Inventory], waiting for it to be hoarded if necessary.
+// The thing is resolved the same way as the [EquipWithOption] function does.
+// If the thing is already hoarded, the function returns it immediately.
+// Otherwise, it blocks until a matching thing is hoarded, including a newly hoarded implementation of a requested interface, or until the given context is done.
+// The function returns the context error if the context is done before the thing is hoarded.
+//
+// To specify custom [Item] name or custom [Inventory] name, use the [EquipOptions] when calling the [EquipWait] function.
+//
+// To specify a custom [Hoarder] to be used, pass the custom [Hoarder] as an argument when calling the [EquipWait] function.
+//
+// The [EquipWait] function is thread-safe.
+//
+// Example usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//
+//	db, err := EquipWait[*sql.DB](ctx, EquipOptions{}.WithCustomItemName("primary"))
+func EquipWait[T any](ctx context.Context, opt EquipOptions, customHoarder ...Hoarder) (T, error) {
+	cfg := defaultEquipConfig
+
+	for _, f := range opt {
+		f.apply(&cfg)
+	}
 
 	typeOfType := reflect.TypeFor[T]()
 
-	var hoarder Hoarder
+	hoarder := pickHoarder(customHoarder...)
 
-	if len(customHoarder) > 0 && customHoarder[0] != nil {
-		hoarder = customHoarder[0]
-	} else {
-		hoarder = globalFactory()
+	inventoryNames := cfg.inventoryNames()
+
+	changed := make(chan struct{}, 1)
+
+	// observe before the first lookup so that a thing hoarded in between is never missed
+	release := observeAcross(hoarder, inventoryNames, func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	})
+	defer release()
+
+	for {
+		// a thing of another type hoarded under the same alias is not the awaited one
+		v, _ := equipAcross(hoarder, typeOfType, inventoryNames, cfg.customItemName)
+		if thing, ok := thingOf[T](v); ok {
+			return thing, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			var zero T
+
+			return zero, ctx.Err()
+		case <-changed:
+		}
 	}
+}
 
-	inventoryName := getCustomInventoryName(customInventoryName)
+// Discard is a function that removes the requested thing from the specified [Inventory].
+// The thing is removed from the exact [Item] name it would be equipped with, and from its alias if the alias holds a thing of type T.
+// Discarding a thing that is not hoarded does nothing.
+//
+// To specify custom [Item] name or custom [Inventory] name, use the [EquipOptions] when calling the [Discard] function.
+//
+// To specify a custom [Hoarder] to be used, pass the custom [Hoarder] as an argument when calling the [Discard] function.
+// Passing a [Tx] discards the thing only once the transaction succeeds.
+//
+// The [Discard] function is thread-safe.
+//
+// Example usage:
+//
+//	Discard[*Cache](nil)
+//	Discard[*Cache](EquipOptions{}.WithCustomInventoryName("customInventoryName").WithCustomItemName("customItemName"), customHoarder)
+func Discard[T any](opt EquipOptions, customHoarder ...Hoarder) {
+	cfg := defaultEquipConfig
 
-	return hoarder.get(typeOfType, inventoryName, customItemName).(T)
+	for _, f := range opt {
+		f.apply(&cfg)
+	}
+
+	hoarder := pickHoarder(customHoarder...)
+
+	hoarder.discard(reflect.TypeFor[T](), getCustomInventoryName(cfg.customInventoryName), cfg.customItemName, newOrigin(0))
+}
+
+// pickHoarder is a function that returns the first given custom [Hoarder] if any, or the global [Hoarder] otherwise.
+func pickHoarder(customHoarder ...Hoarder) Hoarder {
+	if len(customHoarder) > 0 && customHoarder[0] != nil {
+		return customHoarder[0]
+	}
+
+	return globalFactory()
 }
 
 func (h *hoarder) get(typeOfThing reflect.Type, inventoryName, itemName string) interface{} {
-	if typeOfThing.Kind() == reflect.Func {
-		// TODO (oopchi): handle function type
-		return nil
+	if v, _ := h.equip(typeOfThing, inventoryName, itemName); v != nil {
+		return v.use()
+	}
+
+	return nil
+}
+
+func (h *hoarder) equip(typeOfThing reflect.Type, inventoryName, itemName string) (Item, uint64) {
+	return h.report(typeOfThing, inventoryName, itemName, func() (record, ResolutionKind) {
+		return h.lookupLocked(typeOfThing, inventoryName, itemName)
+	})
+}
+
+func (h *hoarder) equipExact(typeOfThing reflect.Type, inventoryName, itemName, thingName string) (Item, uint64) {
+	return h.report(typeOfThing, inventoryName, itemName, func() (record, ResolutionKind) {
+		return h.lookupExactLocked(inventoryName, thingName)
+	})
+}
+
+// report is a method that runs the given lookup requested by the user and reports it to the statistics, the usage tracking and the [Hooks] of the hoarder.
+// The lookup is run while holding the lock of the hoarder, and is only timed, and its [EquipEvent] only built, if [Hooks] are set.
+// The method returns the [Item] and the version of the record returned by the lookup.
+func (h *hoarder) report(typeOfThing reflect.Type, inventoryName, itemName string, lookup func() (record, ResolutionKind)) (Item, uint64) {
+	h.mu.RLock()
+
+	hooks := h.hooks
+	trackUsage := h.trackUsage
+
+	var at time.Time
+	if hooks != nil {
+		at = time.Now()
+	}
+
+	r, resolution := lookup()
+
+	h.mu.RUnlock()
+
+	h.counters.countEquip(statsKey{inventory: inventoryName, typ: typeOfThing}, resolution)
+
+	if trackUsage && r.item != nil {
+		h.equipped.Store(r.hoarding, struct{}{})
+	}
+
+	if hooks != nil {
+		hooks.OnEquip(EquipEvent{
+			Inventory:  getOriginalInventoryName(inventoryName),
+			Type:       typeOfThing.String(),
+			Name:       itemName,
+			Hit:        r.item != nil,
+			Resolution: resolution,
+			At:         at,
+			Latency:    time.Since(at),
+		})
 	}
 
+	return r.item, r.version
+}
+
+func (h *hoarder) resolve(typeOfThing reflect.Type, inventoryName, itemName string) (Item, uint64) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
-	if _, ok := h.inventoryMap[inventoryName]; !ok {
-		return nil
+	r, _ := h.lookupLocked(typeOfThing, inventoryName, itemName)
+
+	return r.item, r.version
+}
+
+// lookupLocked is a method that returns the record describing the slot holding the requested thing in the specified inventory and how it was resolved.
+// The [Item] of the returned record is nil if the thing is not found.
+// Must be called while holding the lock of the hoarder.
+func (h *hoarder) lookupLocked(typeOfThing reflect.Type, inventoryName, itemName string) (record, ResolutionKind) {
+	if typeOfThing.Kind() == reflect.Func {
+		// TODO (oopchi): handle function type
+		return record{}, ResolutionMiss
 	}
 
-	inventoryImpl := h.inventoryMap[inventoryName]
+	// the thing is resolved from the inventory, then from each inventory it inherits from,
+	// which are only walked if the inventory does not hold the thing itself
+	if inventoryImpl, ok := h.inventoryMap[inventoryName]; ok {
+		if r, resolution := resolveFrom(inventoryImpl, typeOfThing, itemName); r.item != nil {
+			return r, resolution
+		}
+	}
 
+	for _, name := range h.appendInventoryChainLocked(make([]string, 0, 4), inventoryName)[1:] {
+		inventoryImpl, ok := h.inventoryMap[name]
+		if !ok {
+			continue
+		}
+
+		if r, resolution := resolveFrom(inventoryImpl, typeOfThing, itemName); r.item != nil {
+			return r, resolution
+		}
+	}
+
+	return record{}, ResolutionMiss
+}
+
+// lookupExactLocked is a method that returns the record describing the slot with the given exact name in the specified inventory.
+// Unlike the lookupLocked method, neither the alias nor the implementations of an interface are looked up.
+// The [Item] of the returned record is nil if the thing is not found.
+// Must be called while holding the lock of the hoarder.
+func (h *hoarder) lookupExactLocked(inventoryName, thingName string) (record, ResolutionKind) {
+	for _, name := range h.appendInventoryChainLocked(make([]string, 0, 4), inventoryName) {
+		inventoryImpl, ok := h.inventoryMap[name]
+		if !ok {
+			continue
+		}
+
+		if r := inventoryImpl.latest(thingName); r.item != nil {
+			return r, ResolutionExact
+		}
+	}
+
+	return record{}, ResolutionMiss
+}
+
+// appendInventoryChainLocked is a method that appends the name of the given inventory followed by the names of the inventories it inherits from, transitively,
+// in resolution order, to the given chain, and returns the extended chain.
+// The parents of an inventory are walked depth-first, and an inventory already in the chain is not walked again.
+// Must be called while holding the lock of the hoarder.
+func (h *hoarder) appendInventoryChainLocked(chain []string, inventoryName string) []string {
+	if slices.Contains(chain, inventoryName) {
+		return chain
+	}
+
+	chain = append(chain, inventoryName)
+
+	if inventoryImpl, ok := h.inventoryMap[inventoryName]; ok {
+		for _, parent := range inventoryImpl.getParents() {
+			chain = h.appendInventoryChainLocked(chain, parent)
+		}
+	}
+
+	return chain
+}
+
+// resolveFrom is a function that returns the record describing the slot holding the requested thing in the given inventory and how it was resolved.
+// The [Item] of the returned record is nil and the resolution is [ResolutionMiss] if the thing is not found.
+func resolveFrom(inventoryImpl Inventory, typeOfThing reflect.Type, itemName string) (record, ResolutionKind) {
 	thingName := getCustomThingName(itemName, typeOfThing)
 
-	if v := inventoryImpl.equip(thingName); v != nil {
-		return v.use()
+	if r := inventoryImpl.latest(thingName); r.item != nil {
+		return r, ResolutionExact
 	}
 
 	aliasName := getAliasThingName(thingName)
 
 	if aliasName != "" {
-		if v := inventoryImpl.equip(aliasName); v != nil {
-			return v.use()
+		if r := inventoryImpl.latest(aliasName); r.item != nil {
+			return r, ResolutionAlias
 		}
 	}
 
 	if typeOfThing.Kind() == reflect.Interface {
-		for _, thing := range inventoryImpl.loadout() {
-			if reflect.TypeOf(thing.use()).Implements(typeOfThing) {
-				return thing.use()
-			}
+		if v := scanFrom(inventoryImpl, typeOfThing); v != nil {
+			r := inventoryImpl.latest(v.getName())
+
+			// the slot may have changed since the item was scanned
+			r.item = v
+
+			return r, ResolutionInterfaceScan
+		}
+	}
+
+	return record{}, ResolutionMiss
+}
+
+// scanFrom is a function that returns the first [Item] of the given inventory implementing the given interface, or nil if none does.
+// The scan is kept apart from the resolveFrom function, whose exact lookups would otherwise pay for the iteration over the inventory.
+func scanFrom(inventoryImpl Inventory, typeOfThing reflect.Type) Item {
+	for _, thing := range inventoryImpl.loadout() {
+		if reflect.TypeOf(thing.use()).Implements(typeOfThing) {
+			return thing
 		}
 	}
 
@@ -380,24 +907,430 @@ func (h *hoarder) merge(hoarder Hoarder) {
 		return
 	}
 
-	if h == hoarder {
-		return
+	_ = h.apply(operation{kind: operationMerge, hoarder: hoarder})
+}
+
+func (h *hoarder) discard(typeOfThing reflect.Type, inventoryName, itemName string, o origin) {
+	_ = h.apply(operation{kind: operationDiscard, typeOfThing: typeOfThing, inventoryName: inventoryName, itemName: itemName, origin: o})
+}
+
+func (h *hoarder) recall(typeOfThing reflect.Type, inventoryName, itemName string) []record {
+	if typeOfThing.Kind() == reflect.Func {
+		// TODO (oopchi): handle function type
+		return nil
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	inventoryImpl, ok := h.inventoryMap[inventoryName]
+	if !ok {
+		return nil
+	}
+
+	return inventoryImpl.recall(slotName(inventoryImpl, typeOfThing, itemName))
+}
+
+func (h *hoarder) apply(operations ...operation) error {
+	// snapshot the given hoarders before acquiring our own lock,
+	// so that two hoarders merging into each other never hold both locks at once
+	snapshots := make([][]inventorySnapshot, len(operations))
+	for i, op := range operations {
+		if op.kind != operationMerge || op.hoarder == nil || op.hoarder == h {
+			continue
+		}
+
+		for _, v := range op.hoarder.loadout() {
+			snapshots[i] = append(snapshots[i], v.snapshot())
+		}
 	}
 
 	h.mu.Lock()
-	defer h.mu.Unlock()
 
-	for k, v := range hoarder.loadout() {
-		if h.inventoryMap == nil {
-			h.inventoryMap = make(map[string]Inventory)
+	auditSink := h.auditSink
+	hooks := h.hooks
+
+	if h.inventoryMap == nil {
+		h.inventoryMap = make(map[string]Inventory)
+	}
+
+	// operations that may fail are applied to copies of the touched inventories,
+	// which replace the original ones only if all the operations succeed
+	fallible := slices.ContainsFunc(operations, func(op operation) bool {
+		return op.kind == operationSwap || op.kind == operationRollback
+	})
+
+	inventoryMap := h.inventoryMap
+	if fallible {
+		inventoryMap = maps.Clone(h.inventoryMap)
+	}
+
+	copied := make(map[string]struct{})
+	use := func(name string) Inventory {
+		if _, ok := inventoryMap[name]; !ok {
+			inventoryMap[name] = newInventoryWithHistory(name)
+			copied[name] = struct{}{}
+		}
+
+		if _, ok := copied[name]; fallible && !ok {
+			inventoryMap[name] = inventoryMap[name].clone()
+			copied[name] = struct{}{}
+		}
+
+		return inventoryMap[name]
+	}
+
+	// changes describes every slot changed by the operations
+	changes := make([]slotChange, 0)
+	track := func(inventoryImpl Inventory, names []string, f func()) {
+		previous := make([]record, 0, 4)
+		for _, name := range names {
+			previous = append(previous, inventoryImpl.latest(name))
+		}
+
+		f()
+
+		for i, name := range names {
+			current := inventoryImpl.latest(name)
+
+			if current.version == previous[i].version {
+				continue
+			}
+
+			changes = append(changes, slotChange{
+				inventoryName: inventoryImpl.getName(),
+				name:          name,
+				previous:      previous[i],
+				current:       current,
+			})
+		}
+	}
+
+	// merges describes every merge applied by the operations, only collected for the [Hooks] if any
+	merges := make([]MergeEvent, 0)
+
+	for i, op := range operations {
+		if op.kind == operationMerge {
+			merge := MergeEvent{
+				Inventories: len(snapshots[i]),
+			}
+
+			if hooks != nil {
+				merge.At = time.Now()
+			}
+
+			changed := len(changes)
+
+			for _, v := range snapshots[i] {
+				inventoryImpl := use(v.name)
+
+				track(inventoryImpl, v.names, func() {
+					inventoryImpl.mergeSnapshot(v)
+				})
+			}
+
+			if hooks != nil {
+				merge.Items = len(changes) - changed
+				merge.Duration = time.Since(merge.At)
+
+				merges = append(merges, merge)
+			}
+
+			continue
 		}
 
-		if _, ok := h.inventoryMap[k]; !ok {
-			h.inventoryMap[k] = v
+		if op.typeOfThing.Kind() == reflect.Func {
+			// TODO (oopchi): handle function type
 			continue
 		}
 
-		h.inventoryMap[k].merge(v)
+		if op.kind == operationDiscard {
+			if _, ok := inventoryMap[op.inventoryName]; ok {
+				inventoryImpl := use(op.inventoryName)
+
+				thingName := getCustomThingName(op.itemName, op.typeOfThing)
+
+				track(inventoryImpl, []string{thingName, getAliasThingName(thingName)}, func() {
+					discardFrom(inventoryImpl, op.typeOfThing, op.itemName, op.origin)
+				})
+			}
+
+			continue
+		}
+
+		inventoryImpl := use(op.inventoryName)
+
+		thingName := slotName(inventoryImpl, op.typeOfThing, op.itemName)
+
+		var item Item
+		if op.thing != nil {
+			item = newItem(op.thing, thingName)
+		}
+
+		if op.kind == operationSwap && inventoryImpl.version(thingName) != op.expectedVersion {
+			h.mu.Unlock()
+
+			return ErrVersionMismatch
+		}
+
+		if op.kind == operationRollback {
+			records := inventoryImpl.recall(thingName)
+
+			if op.steps < 1 || op.steps >= len(records) {
+				h.mu.Unlock()
+
+				return ErrNotEnoughHistory
+			}
+
+			item = nil
+			if v := records[len(records)-1-op.steps].item; v != nil {
+				item = copyItem(v, thingName)
+			}
+		}
+
+		if op.kind == operationSwap {
+			// every slot the swapped thing is hoarded under is swapped along, including its copies shadowed into the default inventory,
+			// so that the new thing is equipped whichever name and inventory the old one was equipped with
+			for _, s := range hoardingSlots(inventoryMap, op.inventoryName, thingName) {
+				siblingImpl := use(s.inventoryName)
+
+				o := op.origin.shadowing(s.shadowOf)
+
+				track(siblingImpl, []string{s.name}, func() {
+					if op.thing == nil {
+						siblingImpl.remove(s.name, o)
+						return
+					}
+
+					siblingImpl.put(newItem(op.thing, s.name), o)
+				})
+			}
+		}
+
+		track(inventoryImpl, []string{thingName}, func() {
+			if item == nil {
+				inventoryImpl.remove(thingName, op.origin)
+				return
+			}
+
+			inventoryImpl.put(item, op.origin)
+		})
+	}
+
+	h.inventoryMap = inventoryMap
+
+	h.mu.Unlock()
+
+	inventoryNames := make([]string, 0, len(changes))
+	for _, c := range changes {
+		inventoryNames = append(inventoryNames, c.inventoryName)
+	}
+
+	h.notify(inventoryNames...)
+
+	for _, c := range changes {
+		h.counters.countChange(c)
+
+		// the audit event is only built for whoever receives it
+		if auditSink == nil && hooks == nil {
+			continue
+		}
+
+		e := newAuditEvent(c)
+
+		if auditSink != nil {
+			auditSink.Audit(e)
+		}
+
+		if hooks == nil {
+			continue
+		}
+
+		switch e.Kind {
+		case AuditRegistered:
+			hooks.OnHoard(e)
+		case AuditOverridden:
+			hooks.OnReplace(e)
+		}
+	}
+
+	if hooks != nil {
+		for _, m := range merges {
+			hooks.OnMerge(m)
+		}
+	}
+
+	return nil
+}
+
+// hoardingSlot is a struct that identifies a slot holding a thing, along with the custom [Inventory] it has been shadowed from.
+type hoardingSlot struct {
+	inventoryName string
+	name          string
+	shadowOf      string
+}
+
+// hoardingSlots is a function that returns every other slot of the given inventories holding the same hoarding as the given slot, sorted by inventory and name.
+// The function returns no slot if the given slot holds no thing, or if its hoarding is unknown, as in an [Inventory] keeping no history.
+func hoardingSlots(inventoryMap map[string]Inventory, inventoryName, name string) []hoardingSlot {
+	r := inventoryMap[inventoryName].latest(name)
+	if r.item == nil || r.hoarding == 0 {
+		return nil
+	}
+
+	slots := make([]hoardingSlot, 0)
+	for _, k := range slices.Sorted(maps.Keys(inventoryMap)) {
+		for n, sibling := range inventoryMap[k].records() {
+			if sibling.item == nil || sibling.hoarding != r.hoarding || k == inventoryName && n == name {
+				continue
+			}
+
+			slots = append(slots, hoardingSlot{inventoryName: k, name: n, shadowOf: sibling.shadowOf})
+		}
+	}
+
+	return slots
+}
+
+// slotName is a function that returns the name of the slot the requested thing is resolved from in the given inventory,
+// or the exact [Item] name of the requested thing if it is not hoarded.
+func slotName(inventoryImpl Inventory, typeOfThing reflect.Type, itemName string) string {
+	if r, _ := resolveFrom(inventoryImpl, typeOfThing, itemName); r.item != nil {
+		return r.item.getName()
+	}
+
+	return getCustomThingName(itemName, typeOfThing)
+}
+
+// discardFrom is a function that removes the requested thing from the given inventory.
+func discardFrom(inventoryImpl Inventory, typeOfThing reflect.Type, itemName string, o origin) {
+	thingName := getCustomThingName(itemName, typeOfThing)
+
+	inventoryImpl.remove(thingName, o)
+
+	aliasName := getAliasThingName(thingName)
+
+	if aliasName == "" {
+		return
+	}
+
+	if v := inventoryImpl.equip(aliasName); v != nil && reflect.TypeOf(v.use()).AssignableTo(typeOfThing) {
+		inventoryImpl.remove(aliasName, o)
+	}
+}
+
+func (h *hoarder) clone() Hoarder {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	inventoryMap := make(map[string]Inventory, len(h.inventoryMap))
+	for k, v := range h.inventoryMap {
+		inventoryMap[k] = v.clone()
+	}
+
+	return &hoarder{
+		mu:           sync.RWMutex{},
+		inventoryMap: inventoryMap,
+		requirements: slices.Clone(h.requirements),
+	}
+}
+
+func (h *hoarder) setAuditSink(auditSink AuditSink) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.auditSink = auditSink
+}
+
+func (h *hoarder) setHooks(hooks Hooks) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.hooks = hooks
+}
+
+func (h *hoarder) setTrackUsage(trackUsage bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.trackUsage = trackUsage
+}
+
+func (h *hoarder) Stats() Stats {
+	equips, registrations := h.counters.snapshot()
+
+	inventories := make([]InventoryStats, 0)
+	for k, v := range h.loadout() {
+		items := 0
+		for range v.loadout() {
+			items++
+		}
+
+		inventories = append(inventories, InventoryStats{
+			Inventory: getOriginalInventoryName(k),
+			Items:     items,
+		})
+	}
+
+	slices.SortFunc(inventories, func(a, b InventoryStats) int {
+		return strings.Compare(a.Inventory, b.Inventory)
+	})
+
+	return Stats{
+		Equips:        equips,
+		Registrations: registrations,
+		Inventories:   inventories,
+	}
+}
+
+func (h *hoarder) Update(fn func(tx *Tx) error) error {
+	return update(h, fn)
+}
+
+func (h *hoarder) observe(inventoryName string, notify func()) func() {
+	o := &observer{
+		inventoryName: inventoryName,
+		notify:        notify,
+	}
+
+	h.observersMu.Lock()
+	defer h.observersMu.Unlock()
+
+	if h.observers == nil {
+		h.observers = make(map[*observer]struct{})
+	}
+
+	h.observers[o] = struct{}{}
+
+	return func() {
+		h.observersMu.Lock()
+		defer h.observersMu.Unlock()
+
+		delete(h.observers, o)
+	}
+}
+
+// notify is a method that calls the functions observing any of the given inventories.
+// The method must not be called while holding the lock of the hoarder.
+func (h *hoarder) notify(inventoryNames ...string) {
+	h.observersMu.Lock()
+	observers := make([]*observer, 0, len(h.observers))
+	for o := range h.observers {
+		observers = append(observers, o)
+	}
+	h.observersMu.Unlock()
+
+	// an inventory also changes whenever an inventory it inherits from does
+	h.mu.RLock()
+	observers = slices.DeleteFunc(observers, func(o *observer) bool {
+		return !slices.ContainsFunc(h.appendInventoryChainLocked(nil, o.inventoryName), func(name string) bool {
+			return slices.Contains(inventoryNames, name)
+		})
+	})
+	h.mu.RUnlock()
+
+	for _, o := range observers {
+		o.notify()
 	}
 }
 
@@ -412,81 +1345,148 @@ func globalFactory() Hoarder {
 }
 
 func initGlobalHoarder(h Hoarder) {
+	initGlobalHoarderWith(func() Hoarder {
+		return h
+	})
+}
+
+// initGlobalHoarderWith is a function that initializes the global hoarder with the hoarder returned by the given function, unless it is already initialized.
+// The given function is only called if the global hoarder is not initialized yet.
+// The function reports whether it initialized the global hoarder.
+func initGlobalHoarderWith(newHoarder func() Hoarder) bool {
+	initialized := false
+
 	once.Do(func() {
-		globalHoarder = h
+		globalHoarder = newHoarder()
+		initialized = true
 	})
+
+	return initialized
 }
 
 func factory(things ...interface{}) Hoarder {
+	return factoryWithOrigin(newOrigin(0), ShadowIfAbsent, things...)
+}
+
+// factoryWithOrigin is a function that creates a new hoarder with the given things, recording the given origin for each of them.
+// Items put into a given [Inventory] keep the origin they were put with instead,
+// and are copied into the default [Inventory] according to its [ShadowMode], or to the given one if it does not specify any.
+func factoryWithOrigin(o origin, shadowMode ShadowMode, things ...interface{}) Hoarder {
 	inventoryMap := make(map[string]Inventory)
-	inventoryMap[defaultInventoryName] = newInventory(defaultInventoryName)
+	inventoryMap[defaultInventoryName] = newInventoryWithHistory(defaultInventoryName)
 	for _, thing := range things {
 		if thing == nil {
 			continue
 		}
 
+		// every thing is hoarded on its own, even when hoarded along with other things
+		o := o.renewed()
+
 		if v, ok := thing.(Inventory); ok {
-			inventoryMap[v.getName()] = newInventory(v.getName())
-
-			// also Put the inventoryImpl items into the default inventoryImpl if absent
-			for _, itemImpl := range v.loadout() {
-				inventoryMap[defaultInventoryName].
-					PutIfAbsent(
-						newItem(
-							itemImpl.use(),
-							getOriginalThingName(itemImpl.getName()),
-						),
-					)
-				inventoryMap[v.getName()].
-					Put(
-						newItem(
-							itemImpl.use(),
-							getOriginalThingName(itemImpl.getName()),
-						),
-					)
+			// the same inventory may be given several times, e.g. by the Wrap method of several keys
+			if _, ok := inventoryMap[v.getName()]; !ok {
+				inventoryMap[v.getName()] = newInventoryWithHistory(v.getName())
+			}
+
+			if parents := v.getParents(); parents != nil {
+				inventoryMap[v.getName()].inheritFrom(parents)
+			}
+
+			// also Put the inventoryImpl items into the default inventoryImpl, according to the shadow mode
+			shadow := func(item Item, o origin) {
+				switch v.getShadowMode().or(shadowMode) {
+				case ShadowIsolated:
+				case ShadowAlways:
+					inventoryMap[defaultInventoryName].put(item, o.shadowing(v.getName()))
+				default:
+					inventoryMap[defaultInventoryName].putIfAbsent(item, o.shadowing(v.getName()))
+				}
+			}
+
+			for _, r := range v.records() {
+				itemImpl := r.item
+
+				shadow(
+					copyItem(
+						itemImpl,
+						getOriginalThingName(itemImpl.getName()),
+					),
+					r.origin,
+				)
+
+				// the exact name of a key only wins over the name of the type of a named key, whatever their order
+				if itemImpl.getMeta().exact && getAliasThingName(itemImpl.getName()) != "" {
+					inventoryMap[v.getName()].
+						putIfAbsent(
+							copyItem(
+								itemImpl,
+								getOriginalThingName(itemImpl.getName()),
+							),
+							r.origin,
+						)
+				} else {
+					inventoryMap[v.getName()].
+						put(
+							copyItem(
+								itemImpl,
+								getOriginalThingName(itemImpl.getName()),
+							),
+							r.origin,
+						)
+				}
 
 				if getAliasThingName(itemImpl.getName()) == "" {
 					continue
 				}
 
-				inventoryMap[defaultInventoryName].
-					PutIfAbsent(
-						newItem(
-							itemImpl.use(),
-							getAliasThingName(itemImpl.getName()),
-						),
-					).
-					PutIfAbsent(
-						newItem(
-							itemImpl.use(),
-							itemImpl.getName(),
-						),
-					)
+				shadow(
+					copyItem(
+						itemImpl,
+						getAliasThingName(itemImpl.getName()),
+					),
+					r.origin,
+				)
+				shadow(
+					copyItem(
+						itemImpl,
+						itemImpl.getName(),
+					),
+					r.origin,
+				)
 
 				inventoryMap[v.getName()].
-					Put(
-						newItem(
-							itemImpl.use(),
+					put(
+						copyItem(
+							itemImpl,
 							getAliasThingName(itemImpl.getName()),
 						),
+						r.origin,
 					).
-					Put(
-						newItem(
-							itemImpl.use(),
+					put(
+						copyItem(
+							itemImpl,
 							itemImpl.getName(),
 						),
+						r.origin,
 					)
 			}
 			continue
 		}
 
 		if v, ok := thing.(Item); ok {
+			// the exact name of a key wins over the name of the type of a named key, whatever their order
+			if v.getMeta().exact && getAliasThingName(v.getName()) == "" {
+				inventoryMap[defaultInventoryName].put(v, o)
+				continue
+			}
+
 			inventoryMap[defaultInventoryName].
-				PutIfAbsent(
-					newItem(
-						v.use(),
+				putIfAbsent(
+					copyItem(
+						v,
 						getOriginalThingName(v.getName()),
 					),
+					o,
 				)
 
 			if getAliasThingName(v.getName()) == "" {
@@ -494,17 +1494,19 @@ func factory(things ...interface{}) Hoarder {
 			}
 
 			inventoryMap[defaultInventoryName].
-				Put(
-					newItem(
-						v.use(),
+				put(
+					copyItem(
+						v,
 						getAliasThingName(v.getName()),
 					),
+					o,
 				).
-				Put(
-					newItem(
-						v.use(),
+				put(
+					copyItem(
+						v,
 						v.getName(),
 					),
+					o,
 				)
 			continue
 		}
@@ -516,7 +1518,7 @@ func factory(things ...interface{}) Hoarder {
 			continue
 		}
 
-		inventoryMap[defaultInventoryName].Put(newItem(thing, thingName))
+		inventoryMap[defaultInventoryName].put(newItem(thing, thingName), o)
 	}
 
 	return &hoarder{
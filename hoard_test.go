@@ -1,8 +1,10 @@
 package hoard
 
 import (
+	"context"
 	"reflect"
 	"sync"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -344,6 +346,35 @@ func (s *suiteTest) Test_initGlobalHoarder() {
 	}
 }
 
+func (s *suiteTest) TestHoard_firstCallReturnsGlobal() {
+	once = sync.Once{}
+	globalHoarder = nil
+
+	defer func() {
+		once = sync.Once{}
+		globalHoarder = nil
+	}()
+
+	recorder := &auditRecorder{}
+
+	first := Hoard(HoardOptions{}.WithAuditSink(recorder), 42)
+	require.Same(s.T(), globalFactory(), first)
+
+	second := Hoard(nil, "test")
+	require.NotSame(s.T(), first, second)
+
+	require.Equal(s.T(), 42, EquipDefault[int](first))
+	require.Equal(s.T(), "test", EquipDefault[string](first))
+
+	// the things of the first call are reported the same way as the following ones
+	got := recorder.got()
+	require.Len(s.T(), got, 2)
+	require.Equal(s.T(), AuditRegistered, got[0].Kind)
+	require.Equal(s.T(), "int", got[0].Type)
+	require.Equal(s.T(), AuditRegistered, got[1].Kind)
+	require.Equal(s.T(), "string", got[1].Type)
+}
+
 func (s *suiteTest) Test_globalFactory() {
 	tests := []struct {
 		name              string
@@ -800,3 +831,150 @@ func (s *suiteTest) Test_loadout_break() {
 		break
 	}
 }
+
+func (s *suiteTest) Test_merge_copiesInventories() {
+	src := Hoard(HoardOptions{}.ShouldReplaceGlobal(false), UseInventory("test").Put(RememberAs("test thing", "test")))
+	dst := factory()
+
+	dst.merge(src)
+
+	for k, v := range src.loadout() {
+		dst.(*hoarder).mu.RLock()
+		got := dst.(*hoarder).inventoryMap[k]
+		dst.(*hoarder).mu.RUnlock()
+
+		require.NotSame(s.T(), v, got)
+	}
+
+	src.merge(factory(UseInventory("test").Put(RememberAs("test thing2", "test"))))
+
+	require.Equal(s.T(), "test thing", dst.get(reflect.TypeFor[string](), getCustomInventoryName("test"), "test"))
+	require.Equal(s.T(), "test thing2", src.get(reflect.TypeFor[string](), getCustomInventoryName("test"), "test"))
+}
+
+func (s *suiteTest) Test_merge_concurrent() {
+	tests := []struct {
+		name       string
+		goroutines int
+	}{
+		{
+			name:       "merging hoarders into each other concurrently should not deadlock nor race",
+			goroutines: 50,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			a := factory("a", UseInventory("test").Put(RememberAs("a", "a")))
+			b := factory("b", UseInventory("test").Put(RememberAs("b", "b")))
+
+			done := make(chan struct{})
+
+			go func() {
+				defer close(done)
+
+				wg := sync.WaitGroup{}
+				for i := 0; i < tt.goroutines; i++ {
+					wg.Add(4)
+					go func() {
+						defer wg.Done()
+						a.merge(b)
+					}()
+					go func() {
+						defer wg.Done()
+						b.merge(a)
+					}()
+					go func() {
+						defer wg.Done()
+						Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(a), b)
+					}()
+					go func() {
+						defer wg.Done()
+						_ = a.get(reflect.TypeFor[string](), getCustomInventoryName("test"), "b")
+						_ = b.get(reflect.TypeFor[string](), getCustomInventoryName("test"), "a")
+					}()
+				}
+				wg.Wait()
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(10 * time.Second):
+				s.FailNow("merging hoarders into each other deadlocked")
+			}
+
+			require.Equal(s.T(), "a", a.get(reflect.TypeFor[string](), getCustomInventoryName("test"), "a"))
+			require.Equal(s.T(), "b", a.get(reflect.TypeFor[string](), getCustomInventoryName("test"), "b"))
+			require.Equal(s.T(), "a", b.get(reflect.TypeFor[string](), getCustomInventoryName("test"), "a"))
+			require.Equal(s.T(), "b", b.get(reflect.TypeFor[string](), getCustomInventoryName("test"), "b"))
+		})
+	}
+}
+
+func (s *suiteTest) TestEquipWait() {
+	tests := []struct {
+		name        string
+		givenOption EquipOptions
+		givenBefore []interface{}
+		givenAfter  []interface{}
+		wantErr     error
+		want        TestFooer
+	}{
+		{
+			name:        "should return immediately if the thing is already hoarded",
+			givenOption: nil,
+			givenBefore: []interface{}{TestFooImpl{Name: "test"}},
+			givenAfter:  nil,
+			want:        TestFooImpl{Name: "test"},
+		},
+		{
+			name:        "should wait until an implementation of the interface is hoarded",
+			givenOption: nil,
+			givenBefore: []interface{}{"test"},
+			givenAfter:  []interface{}{TestFooImpl{Name: "test2"}},
+			want:        TestFooImpl{Name: "test2"},
+		},
+		{
+			name:        "should wait until the thing is hoarded in a custom inventory",
+			givenOption: EquipOptions{}.WithCustomInventoryName("test").WithCustomItemName("test"),
+			givenBefore: nil,
+			givenAfter:  []interface{}{UseInventory("test").Put(RememberAs(&TestFooImpl{Name: "test3"}, "test"))},
+			want:        &TestFooImpl{Name: "test3"},
+		},
+		{
+			name:        "should keep waiting if a thing of another type is hoarded under the alias",
+			givenOption: EquipOptions{}.WithCustomItemName("y"),
+			givenBefore: nil,
+			givenAfter:  []interface{}{RememberAs(42, "y")},
+			wantErr:     context.DeadlineExceeded,
+			want:        nil,
+		},
+		{
+			name:        "should return the context error if the thing is never hoarded",
+			givenOption: EquipOptions{}.WithCustomItemName("test"),
+			givenBefore: []interface{}{"test"},
+			givenAfter:  []interface{}{"test2"},
+			wantErr:     context.DeadlineExceeded,
+			want:        nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			h := factory(tt.givenBefore...)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+
+			go func() {
+				time.Sleep(50 * time.Millisecond)
+				Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), tt.givenAfter...)
+			}()
+
+			got, err := EquipWait[TestFooer](ctx, tt.givenOption, h)
+
+			require.ErrorIs(s.T(), err, tt.wantErr)
+			require.Equal(s.T(), tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Package hoardcheck defines an [analysis.Analyzer] that cross-checks the call sites hoarding things with the call sites equipping them.
+//
+// The analyzer reports:
+//   - things of a function type given to the hoard functions, which are silently ignored by the [hoard.Hoard] function and never found by the equip functions,
+//   - inventory names used in [hoard.EquipOptions] but never given to the [hoard.UseInventory] function,
+//   - item names used in [hoard.EquipOptions] but never given to the [hoard.RememberAs] function in that inventory,
+//   - type arguments of the equip functions, such as [hoard.EquipDefault] or [hoard.EquipWithOption], that are never hoarded.
+//
+// The last three checks need the whole program, hence they are only reported when analyzing a main package.
+// The equip call sites of the imported packages are reported on the package clause of the main package.
+//
+// Things hoarded or equipped through values unknown at compile time, such as an [hoard.Item] variable or a non-constant name, are assumed to match,
+// and so are the things equipped from an inventory declared with the [hoard.InheritsFrom] option, with fallback inventories,
+// with parent inventories walked up or with an inventory pattern.
+// Things equipped with the [hoard.EquipOr] or [hoard.EquipAll] functions are never reported, since they cannot fail.
+package hoardcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"slices"
+	"strings"
+
+	"github.com/oopchi/hoard/internal/callsite"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer is the [analysis.Analyzer] cross-checking the call sites hoarding things with the call sites equipping them.
+var Analyzer = &analysis.Analyzer{
+	Name:      "hoardcheck",
+	Doc:       "check that every equipped thing is hoarded somewhere in the program",
+	URL:       "https://pkg.go.dev/github.com/oopchi/hoard/hoardcheck",
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	FactTypes: []analysis.Fact{new(usage)},
+	Run:       run,
+}
+
+// usage is the package fact holding the things hoarded and equipped by a package.
+type usage struct {
+	Hoarded  []callsite.Site
+	Equipped []callsite.Site
+}
+
+// AFact is a method that marks [usage] as an [analysis.Fact].
+func (*usage) AFact() {}
+
+// String is a method that returns a human-readable description of the [usage].
+func (u *usage) String() string {
+	return fmt.Sprintf("usage(%d hoarded, %d equipped)", len(u.Hoarded), len(u.Equipped))
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	if pass.Pkg.Path() == callsite.HoardPath || strings.HasPrefix(pass.Pkg.Path(), callsite.HoardPath+"/") {
+		return nil, nil
+	}
+
+	c := callsite.NewCollector(pass.Fset, pass.Pkg, pass.TypesInfo, pass.Reportf)
+
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		c.Visit(n.(*ast.CallExpr))
+	})
+
+	if len(c.Hoarded) > 0 || len(c.Equipped) > 0 {
+		pass.ExportPackageFact(&usage{
+			Hoarded:  c.Hoarded,
+			Equipped: c.Equipped,
+		})
+	}
+
+	if pass.Pkg.Name() == "main" {
+		check(pass, c)
+	}
+
+	return nil, nil
+}
+
+// check is a function that reports the things equipped by the program but never hoarded.
+func check(pass *analysis.Pass, c *callsite.Collector) {
+	hoarded := slices.Clone(c.Hoarded)
+	imported := make([]callsite.Site, 0)
+
+	for _, f := range pass.AllPackageFacts() {
+		u, ok := f.Fact.(*usage)
+		if !ok || f.Package == pass.Pkg {
+			continue
+		}
+
+		hoarded = append(hoarded, u.Hoarded...)
+		imported = append(imported, u.Equipped...)
+	}
+
+	slices.SortFunc(imported, func(a, b callsite.Site) int {
+		return strings.Compare(a.Position, b.Position)
+	})
+
+	r := callsite.NewResolver(pass.Pkg)
+
+	for _, e := range c.Equipped {
+		if e.Func == "EquipOr" || e.Func == "EquipAll" {
+			continue
+		}
+
+		if msg := r.Missing(e, hoarded); msg != "" {
+			pass.Reportf(e.Pos(), "hoard: %s", msg)
+		}
+	}
+
+	for _, e := range imported {
+		if e.Func == "EquipOr" || e.Func == "EquipAll" {
+			continue
+		}
+
+		if msg := r.Missing(e, hoarded); msg != "" {
+			pass.Reportf(pass.Files[0].Name.Pos(), "hoard: %s (equipped at %s)", msg, e.Position)
+		}
+	}
+}
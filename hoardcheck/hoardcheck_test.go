@@ -0,0 +1,12 @@
+package hoardcheck_test
+
+import (
+	"testing"
+
+	"github.com/oopchi/hoard/hoardcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), hoardcheck.Analyzer, "lib", "app", "dynamic", "inherit", "hierarchy", "label")
+}
@@ -0,0 +1,47 @@
+package main // want package:`usage\(4 hoarded, 14 equipped\)` `hoard: \*lib.Cache in inventory default is never hoarded \(equipped at lib/lib.go:25\)`
+
+import (
+	"context"
+
+	"github.com/oopchi/hoard"
+
+	"lib"
+)
+
+type Config struct {
+	Port int
+}
+
+type Handler func()
+
+func main() {
+	lib.Register()
+
+	hoard.Hoard(nil, Config{}, 42, hoard.RememberAs("secret", "token"))
+	hoard.Hoard(nil, Handler(func() {}))               // want `hoard: cannot hoard function type main.Handler, it is silently ignored`
+	hoard.Hoard(nil, hoard.RememberAs(func() {}, "f")) // want `hoard: cannot hoard function type func\(\), it is silently ignored`
+
+	_ = hoard.EquipDefault[Config]()
+	_ = hoard.EquipDefault[int]()
+	_ = hoard.EquipDefault[lib.Greeter]()
+	_ = hoard.EquipDefault[*Config]() // want `hoard: \*main.Config in inventory default is never hoarded`
+	_ = hoard.EquipWithOption[string](hoard.EquipOptions{}.WithCustomItemName("token"))
+	_ = hoard.EquipWithOption[string](hoard.EquipOptions{}.WithCustomItemName("tokn")) // want `hoard: item name "tokn" is never registered in inventory default`
+	_ = hoard.EquipWithOption[int](hoard.EquipOptions{}.WithCustomItemName("token"))   // want `hoard: int named "token" in inventory default is never hoarded`
+	_ = hoard.EquipWithOption[lib.English](hoard.EquipOptions{}.WithCustomInventoryName("greeting").WithCustomItemName("english"))
+	_ = hoard.EquipWithOption[lib.English](hoard.EquipOptions{}.WithCustomInventoryName("greetings")) // want `hoard: inventory "greetings" is never registered`
+	_, _ = hoard.EquipWait[Handler](context.Background(), nil)                                        // want `hoard: cannot use function type main.Handler with EquipWait, it is never hoarded`
+	_ = hoard.Need[*lib.Client](nil)
+	_ = hoard.Ref[bool](nil) // want `hoard: bool in inventory default is never hoarded`
+
+	name := "dynamic"
+	_ = hoard.EquipWithOption[string](hoard.EquipOptions{}.WithCustomItemName(name))
+
+	tx := &hoard.Tx{}
+	tx.Put(uint8(1))
+	_ = hoard.EquipDefault[byte]()
+}
+
+func equip[T any]() T {
+	return hoard.EquipDefault[T]()
+}
@@ -0,0 +1,14 @@
+package main // want package:`usage\(1 hoarded, 2 equipped\)`
+
+import (
+	"github.com/oopchi/hoard"
+)
+
+func main() {
+	_ = hoard.EquipWithOption[float64](hoard.EquipOptions{}.WithCustomInventoryName("dynamic").WithCustomItemName("pi"))
+	_ = hoard.EquipWithOption[float64](hoard.EquipOptions{}.WithCustomInventoryName("static")) // want `hoard: inventory "static" is never registered`
+}
+
+func hoardItem(item hoard.Item) {
+	hoard.Hoard(nil, hoard.UseInventory("dynamic").Put(item))
+}
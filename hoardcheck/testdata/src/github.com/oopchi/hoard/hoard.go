@@ -0,0 +1,81 @@
+// Package hoard is a stub of the hoard package, mirroring the signatures checked by the hoardcheck analyzer.
+package hoard
+
+import "context"
+
+type HoardOptions []func()
+
+type EquipOptions []func()
+
+func (h EquipOptions) WithCustomInventoryName(customInventoryName string) EquipOptions { return h }
+
+func (h EquipOptions) WithCustomItemName(customItemName string) EquipOptions { return h }
+
+func (h EquipOptions) WithFallbackInventories(customInventoryNames ...string) EquipOptions { return h }
+
+func (h EquipOptions) ShouldWalkUpInventories(walkUpInventories bool) EquipOptions { return h }
+
+func (h EquipOptions) WithSelector(selector string) EquipOptions { return h }
+
+func (h EquipOptions) WithInventoryPattern(inventoryPattern string) EquipOptions { return h }
+
+type Hoarder interface{}
+
+type Item interface{ use() interface{} }
+
+type Inventory interface {
+	Put(item Item) Inventory
+	PutIfAbsent(item Item) Inventory
+}
+
+type Tx struct{}
+
+func (tx *Tx) Put(things ...interface{}) *Tx { return tx }
+
+type Reference[T any] struct{}
+
+type Requirement struct{}
+
+func Hoard(opt HoardOptions, things ...interface{}) Hoarder { return nil }
+
+func RememberAs(thing interface{}, name string) Item { return nil }
+
+func Reveal(thing interface{}) Item { return nil }
+
+func Label(thing interface{}, labels map[string]string) Item { return nil }
+
+type InventoryOption struct{}
+
+func InheritsFrom(parents ...string) InventoryOption { return InventoryOption{} }
+
+type ShadowMode int
+
+const (
+	ShadowIfAbsent ShadowMode = iota + 1
+	ShadowIsolated
+	ShadowAlways
+)
+
+func WithShadowMode(mode ShadowMode) InventoryOption { return InventoryOption{} }
+
+func UseInventory(name string, opts ...InventoryOption) Inventory { return nil }
+
+func EquipDefault[T any](customHoarder ...Hoarder) T { panic("stub") }
+
+func EquipWithOption[T any](opt EquipOptions, customHoarder ...Hoarder) T { panic("stub") }
+
+func EquipOr[T any](opt EquipOptions, fallback T, customHoarder ...Hoarder) T { return fallback }
+
+func EquipAll[T any](opt EquipOptions, customHoarder ...Hoarder) []T { return nil }
+
+func EquipVersioned[T any](opt EquipOptions, customHoarder ...Hoarder) (T, uint64) { panic("stub") }
+
+func EquipWait[T any](ctx context.Context, opt EquipOptions, customHoarder ...Hoarder) (T, error) {
+	panic("stub")
+}
+
+func Ref[T any](opt EquipOptions, customHoarder ...Hoarder) *Reference[T] { return nil }
+
+func Need[T any](opt EquipOptions) Requirement { return Requirement{} }
+
+func Discard[T any](opt EquipOptions, customHoarder ...Hoarder) {}
@@ -0,0 +1,17 @@
+package main // want package:`usage\(2 hoarded, 4 equipped\)`
+
+import (
+	"github.com/oopchi/hoard"
+)
+
+func main() {
+	hoard.Hoard(nil,
+		hoard.UseInventory("payments").Put(hoard.RememberAs(8080, "port")),
+		hoard.UseInventory("payments/eu").Put(hoard.RememberAs(true, "")),
+	)
+
+	_ = hoard.EquipWithOption[int](hoard.EquipOptions{}.WithCustomInventoryName("payments/eu/primary").ShouldWalkUpInventories(true))
+	_ = hoard.EquipAll[bool](hoard.EquipOptions{}.WithInventoryPattern("payments/**"))
+	_ = hoard.EquipAll[string](nil)
+	_ = hoard.EquipWithOption[int](hoard.EquipOptions{}.WithCustomInventoryName("payments/eu/primary")) // want `hoard: inventory "payments/eu/primary" is never registered`
+}
@@ -0,0 +1,22 @@
+package main // want package:`usage\(4 hoarded, 8 equipped\)`
+
+import (
+	"github.com/oopchi/hoard"
+)
+
+func main() {
+	hoard.Hoard(nil,
+		hoard.UseInventory("prod").Put(hoard.RememberAs(8080, "port")),
+		hoard.UseInventory("staging", hoard.InheritsFrom("prod")).Put(hoard.RememberAs(true, "")),
+		hoard.UseInventory("isolated", hoard.WithShadowMode(hoard.ShadowIsolated)).Put(hoard.RememberAs(uint8(1), "")),
+	)
+
+	_ = hoard.EquipWithOption[int](hoard.EquipOptions{}.WithCustomInventoryName("staging").WithCustomItemName("port"))
+	_ = hoard.EquipWithOption[string](hoard.EquipOptions{}.WithCustomInventoryName("staging"))
+	_ = hoard.EquipDefault[string]() // want `hoard: string in inventory default is never hoarded`
+	_ = hoard.EquipWithOption[float64](hoard.EquipOptions{}.WithCustomInventoryName("tenant").WithFallbackInventories("prod"))
+	_ = hoard.EquipWithOption[float64](hoard.EquipOptions{}.WithCustomInventoryName("tenant")) // want `hoard: inventory "tenant" is never registered`
+	_ = hoard.EquipOr[uint](nil, 1)
+	_ = hoard.EquipWithOption[uint8](hoard.EquipOptions{}.WithCustomInventoryName("isolated"))
+	_ = hoard.EquipWithOption[int16](hoard.EquipOptions{}.WithCustomInventoryName("isolated")) // want `hoard: int16 in inventory isolated is never hoarded`
+}
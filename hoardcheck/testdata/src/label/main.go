@@ -0,0 +1,18 @@
+package main // want package:`usage\(3 hoarded, 4 equipped\)`
+
+import (
+	"github.com/oopchi/hoard"
+)
+
+func main() {
+	hoard.Hoard(nil,
+		hoard.Label(hoard.RememberAs(8080, "port"), map[string]string{"region": "eu"}),
+		hoard.Label(true, map[string]string{"region": "us"}),
+		hoard.UseInventory("payments").Put(hoard.Label(hoard.RememberAs(1.5, ""), map[string]string{"tier": "gold"})),
+	)
+
+	_ = hoard.EquipWithOption[int](hoard.EquipOptions{}.WithCustomItemName("port").WithSelector("region=eu"))
+	_ = hoard.EquipWithOption[bool](hoard.EquipOptions{}.WithSelector("region=us"))
+	_ = hoard.EquipWithOption[float64](hoard.EquipOptions{}.WithCustomInventoryName("payments").WithSelector("tier"))
+	_ = hoard.EquipWithOption[string](hoard.EquipOptions{}.WithSelector("region=eu")) // want `hoard: string in inventory default is never hoarded`
+}
@@ -0,0 +1,28 @@
+package lib // want package:`usage\(2 hoarded, 3 equipped\)`
+
+import (
+	"github.com/oopchi/hoard"
+)
+
+type Client struct{}
+
+type Cache struct{}
+
+type Greeter interface {
+	Greet() string
+}
+
+type English struct{}
+
+func (English) Greet() string { return "hello" }
+
+func Register() {
+	hoard.Hoard(nil, &Client{}, hoard.UseInventory("greeting").Put(hoard.RememberAs(English{}, "english")))
+}
+
+func Use() {
+	_ = hoard.EquipDefault[*Client]()
+	_ = hoard.EquipDefault[*Cache]()
+	_ = hoard.EquipWithOption[Greeter](hoard.EquipOptions{}.WithCustomInventoryName("greeting"))
+	_ = hoard.EquipDefault[func()]() // want `hoard: cannot use function type func\(\) with EquipDefault, it is never hoarded`
+}
@@ -0,0 +1,150 @@
+// Package hoardtest provides helpers to test programs using the hoard package.
+//
+// The package registers the -hoardtest.update flag in the test binaries importing it.
+// Running the tests with the flag rewrites the golden files compared by the [AssertSnapshot] function:
+//
+//	go test ./... -hoardtest.update
+//
+// The flag is namespaced, so that it never clashes with an -update flag declared by the tests themselves.
+package hoardtest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/oopchi/hoard"
+)
+
+// update is the -hoardtest.update flag, which rewrites the golden files of the [AssertSnapshot] function instead of comparing them.
+var update = flag.Bool("hoardtest.update", false, "rewrite the golden files of hoardtest.AssertSnapshot")
+
+// Snapshot is a function that returns the shape of the given [hoard.Hoarder] as text:
+// its inventories, the keys of their slots, the types of the things and their custom item names, but not the things themselves.
+// The global [hoard.Hoarder] is used if the given [hoard.Hoarder] is nil.
+// The text is deterministic, hence suitable for a golden file.
+// Example output:
+//
+//	inventory "cache"
+//		"*github.com/acme/dbDB" *db.DB
+//	inventory "default"
+//		"*github.com/acme/dbDB" *db.DB shadow-of="cache"
+//		"int" int
+func Snapshot(h hoard.Hoarder) string {
+	b := strings.Builder{}
+	inventory := ""
+
+	for i, s := range hoard.Slots(h) {
+		if i == 0 || s.Inventory != inventory {
+			inventory = s.Inventory
+			b.WriteString("inventory " + strconv.Quote(inventory) + "\n")
+		}
+
+		b.WriteString("\t" + strconv.Quote(s.Key) + " " + s.Type)
+
+		if s.Name != "" {
+			b.WriteString(" name=" + strconv.Quote(s.Name))
+		}
+
+		if s.ShadowOf != "" {
+			b.WriteString(" shadow-of=" + strconv.Quote(s.ShadowOf))
+		}
+
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// AssertSnapshot is a function that compares the [Snapshot] of the given [hoard.Hoarder] with the given golden file, and fails the test if they differ.
+// The function reports whether they are equal.
+// When the tests are run with the -hoardtest.update flag, the golden file is rewritten instead.
+//
+// Example usage:
+//
+//	func TestRegistry(t *testing.T) {
+//		hoardtest.AssertSnapshot(t, app.Hoarder(), "testdata/registry.golden")
+//	}
+//
+// To rewrite the golden file:
+//
+//	go test -run TestRegistry -hoardtest.update
+func AssertSnapshot(t testing.TB, h hoard.Hoarder, golden string) bool {
+	t.Helper()
+
+	got := Snapshot(h)
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+			t.Errorf("hoardtest: cannot create the directory of %s: %v", golden, err)
+			return false
+		}
+
+		if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+			t.Errorf("hoardtest: cannot write %s: %v", golden, err)
+			return false
+		}
+
+		return true
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Errorf("hoardtest: cannot read %s, run the tests with -hoardtest.update to create it: %v", golden, err)
+		return false
+	}
+
+	if string(want) == got {
+		return true
+	}
+
+	t.Errorf("hoardtest: the hoarder does not match %s, run the tests with -hoardtest.update to rewrite it:\n%s", golden, diffLines(string(want), got))
+
+	return false
+}
+
+// diffLines is a function that returns the lines of want missing from got prefixed with "-", and the lines of got missing from want prefixed with "+".
+// The lines are compared with their longest common subsequence, so the unchanged lines are prefixed with a space.
+func diffLines(want, got string) string {
+	a := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	out := strings.Builder{}
+	i, j := 0, 0
+
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			out.WriteString("  " + a[i] + "\n")
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			out.WriteString("+ " + b[j] + "\n")
+			j++
+		default:
+			out.WriteString("- " + a[i] + "\n")
+			i++
+		}
+	}
+
+	return out.String()
+}
@@ -0,0 +1,138 @@
+package hoardtest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oopchi/hoard"
+	"github.com/stretchr/testify/require"
+)
+
+type Store struct{}
+
+type Cache struct{}
+
+// fakeT is a [testing.TB] recording the errors instead of failing the test.
+type fakeT struct {
+	testing.TB
+	errors []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func newHoarder(things ...interface{}) hoard.Hoarder {
+	return hoard.Hoard(hoard.HoardOptions{}.ShouldReplaceGlobal(false), things...)
+}
+
+func TestSnapshot(t *testing.T) {
+	h := newHoarder(
+		&Store{},
+		hoard.RememberAs(42, "answer"),
+		hoard.UseInventory("cache").Put(hoard.RememberAs(&Cache{}, "lru")),
+	)
+
+	require.Equal(t, `inventory "cache"
+	"*github.com/oopchi/hoard/hoardtestCache" *hoardtest.Cache
+	"*github.com/oopchi/hoard/hoardtestCache\nlru" *hoardtest.Cache name="lru"
+	"lru" *hoardtest.Cache name="lru"
+inventory "default"
+	"*github.com/oopchi/hoard/hoardtestCache" *hoardtest.Cache shadow-of="cache"
+	"*github.com/oopchi/hoard/hoardtestCache\nlru" *hoardtest.Cache name="lru" shadow-of="cache"
+	"*github.com/oopchi/hoard/hoardtestStore" *hoardtest.Store
+	"answer" int name="answer"
+	"int" int
+	"int\nanswer" int name="answer"
+	"lru" *hoardtest.Cache name="lru" shadow-of="cache"
+`, Snapshot(h))
+}
+
+func TestAssertSnapshot(t *testing.T) {
+	h := newHoarder(
+		&Store{},
+		hoard.RememberAs(42, "answer"),
+		hoard.UseInventory("cache").Put(hoard.RememberAs(&Cache{}, "lru")),
+	)
+
+	tests := []struct {
+		name   string
+		h      hoard.Hoarder
+		golden string
+		want   bool
+		errors []string
+	}{
+		{
+			name:   "should match the golden file",
+			h:      h,
+			golden: "testdata/registry.golden",
+			want:   true,
+		},
+		{
+			name:   "should not match the golden file if the shape differs",
+			h:      newHoarder(&Store{}, hoard.RememberAs(42, "answer")),
+			golden: "testdata/registry.golden",
+			errors: []string{`hoardtest: the hoarder does not match testdata/registry.golden, run the tests with -hoardtest.update to rewrite it:
+- inventory "cache"
+- 	"*github.com/oopchi/hoard/hoardtestCache" *hoardtest.Cache
+- 	"*github.com/oopchi/hoard/hoardtestCache\nlru" *hoardtest.Cache name="lru"
+- 	"lru" *hoardtest.Cache name="lru"
+  inventory "default"
+- 	"*github.com/oopchi/hoard/hoardtestCache" *hoardtest.Cache shadow-of="cache"
+- 	"*github.com/oopchi/hoard/hoardtestCache\nlru" *hoardtest.Cache name="lru" shadow-of="cache"
+  	"*github.com/oopchi/hoard/hoardtestStore" *hoardtest.Store
+  	"answer" int name="answer"
+  	"int" int
+  	"int\nanswer" int name="answer"
+- 	"lru" *hoardtest.Cache name="lru" shadow-of="cache"
+`},
+		},
+		{
+			name:   "should fail if the golden file does not exist",
+			h:      h,
+			golden: "testdata/missing.golden",
+			errors: []string{"hoardtest: cannot read testdata/missing.golden, run the tests with -hoardtest.update to create it: open testdata/missing.golden: no such file or directory"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeT{TB: t}
+
+			require.Equal(t, tt.want, AssertSnapshot(f, tt.h, tt.golden))
+			require.Equal(t, tt.errors, f.errors)
+		})
+	}
+}
+
+func TestAssertSnapshot_update(t *testing.T) {
+	update := flag.Lookup("hoardtest.update").Value.String()
+	require.NoError(t, flag.Set("hoardtest.update", "true"))
+	t.Cleanup(func() {
+		require.NoError(t, flag.Set("hoardtest.update", update))
+	})
+
+	golden := filepath.Join(t.TempDir(), "testdata", "registry.golden")
+	h := newHoarder(&Store{})
+
+	f := &fakeT{TB: t}
+	require.True(t, AssertSnapshot(f, h, golden))
+	require.Empty(t, f.errors)
+
+	b, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	require.Equal(t, Snapshot(h), string(b))
+}
+
+func TestUpdateFlag(t *testing.T) {
+	// the tests importing the package may declare their own -update flag
+	require.Nil(t, flag.Lookup("update"))
+	require.NotPanics(t, func() {
+		flag.Bool("update", false, "rewrite the golden files of the tests")
+	})
+}
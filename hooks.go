@@ -0,0 +1,173 @@
+package hoard
+
+import (
+	"time"
+)
+
+// ResolutionKind is a type that describes how a requested thing was resolved from an [Inventory].
+type ResolutionKind int
+
+const (
+	// ResolutionExact means the thing was found under its exact [Item] name, i.e. its type and custom [Item] name if any.
+	ResolutionExact ResolutionKind = iota + 1
+
+	// ResolutionAlias means the thing was found under its custom [Item] name only.
+	ResolutionAlias
+
+	// ResolutionInterfaceScan means the requested type is an interface and the thing was found by scanning the [Inventory] for an implementation.
+	ResolutionInterfaceScan
+
+	// ResolutionMiss means the thing was not found.
+	ResolutionMiss
+)
+
+// String is a method that returns the name of the [ResolutionKind].
+func (k ResolutionKind) String() string {
+	switch k {
+	case ResolutionExact:
+		return "exact"
+	case ResolutionAlias:
+		return "alias"
+	case ResolutionInterfaceScan:
+		return "interface scan"
+	case ResolutionMiss:
+		return "miss"
+	}
+
+	return "unknown"
+}
+
+// EquipEvent is a struct that describes a single lookup of a thing from a [Hoarder].
+// The struct is delivered to the [Hooks.OnEquip] method.
+type EquipEvent struct {
+
+	// Inventory is the name of the [Inventory] the thing was looked up from, as given to the [UseInventory] function, or "default" for the default one.
+	Inventory string
+
+	// Type is the requested type.
+	Type string
+
+	// Name is the requested custom [Item] name, or an empty string if none.
+	Name string
+
+	// Hit is a boolean that reports whether the thing was found.
+	Hit bool
+
+	// Resolution describes how the thing was resolved.
+	Resolution ResolutionKind
+
+	// At is the time the lookup started.
+	At time.Time
+
+	// Latency is the time the lookup took.
+	Latency time.Duration
+}
+
+// MergeEvent is a struct that describes a single merge of a [Hoarder] into another one, e.g. by the [Hoard] function.
+// The struct is delivered to the [Hooks.OnMerge] method.
+type MergeEvent struct {
+
+	// Inventories is the number of inventories merged.
+	Inventories int
+
+	// Items is the number of slots changed by the merge.
+	Items int
+
+	// At is the time the merge started.
+	At time.Time
+
+	// Duration is the time the merge took.
+	Duration time.Duration
+}
+
+// Hooks is an interface that is called on every hoard, replacement, equip and merge of a [Hoarder].
+// Set it with the [HoardOptions.WithHooks] method.
+// The hooks are called synchronously without holding any lock of the [Hoarder],
+// hence they must be safe for concurrent use and should return quickly.
+// Embed [NopHooks] to implement only some of the methods.
+type Hooks interface {
+
+	// OnHoard is called whenever a thing is hoarded into an empty slot.
+	OnHoard(event AuditEvent)
+
+	// OnReplace is called whenever a thing is hoarded into a slot already holding another thing.
+	OnReplace(event AuditEvent)
+
+	// OnEquip is called whenever a thing is requested, e.g. by the [EquipWithOption] function, whether it was found or not.
+	OnEquip(event EquipEvent)
+
+	// OnMerge is called whenever a [Hoarder] is merged into the [Hoarder], after the hooks of the slots it changed.
+	OnMerge(event MergeEvent)
+}
+
+// NopHooks is a struct that implements the [Hooks] interface doing nothing.
+// Embed it to implement only some of the methods of the [Hooks] interface.
+// Example usage:
+//
+//	type missHooks struct {
+//		NopHooks
+//	}
+//
+//	func (missHooks) OnEquip(e EquipEvent) {
+//		if !e.Hit {
+//			log.Println("missing", e.Type)
+//		}
+//	}
+type NopHooks struct{}
+
+// OnHoard is a method that does nothing.
+func (NopHooks) OnHoard(AuditEvent) {}
+
+// OnReplace is a method that does nothing.
+func (NopHooks) OnReplace(AuditEvent) {}
+
+// OnEquip is a method that does nothing.
+func (NopHooks) OnEquip(EquipEvent) {}
+
+// OnMerge is a method that does nothing.
+func (NopHooks) OnMerge(MergeEvent) {}
+
+// JoinHooks is a function that returns [Hooks] calling each of the given [Hooks] in order.
+// Nil [Hooks] are ignored.
+// Example usage:
+//
+//	Hoard(HoardOptions{}.WithHooks(JoinHooks(SlogHooks(logger), SpanHooks(tracer))))
+func JoinHooks(hooks ...Hooks) Hooks {
+	joined := make(joinedHooks, 0, len(hooks))
+	for _, h := range hooks {
+		if h != nil {
+			joined = append(joined, h)
+		}
+	}
+
+	return joined
+}
+
+// joinedHooks is a type that implements the [Hooks] interface by calling each of its [Hooks] in order.
+// This type is used internally and should not be used directly.
+// To join [Hooks], use the [JoinHooks] function instead.
+type joinedHooks []Hooks
+
+func (j joinedHooks) OnHoard(event AuditEvent) {
+	for _, h := range j {
+		h.OnHoard(event)
+	}
+}
+
+func (j joinedHooks) OnReplace(event AuditEvent) {
+	for _, h := range j {
+		h.OnReplace(event)
+	}
+}
+
+func (j joinedHooks) OnEquip(event EquipEvent) {
+	for _, h := range j {
+		h.OnEquip(event)
+	}
+}
+
+func (j joinedHooks) OnMerge(event MergeEvent) {
+	for _, h := range j {
+		h.OnMerge(event)
+	}
+}
@@ -0,0 +1,78 @@
+package hoard
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogHooks is a function that returns [Hooks] logging every hoard, replacement, equip and merge to the given logger at debug level.
+// The [slog.Default] logger is used if the given logger is nil.
+// Example usage:
+//
+//	Hoard(HoardOptions{}.WithHooks(SlogHooks(slog.Default())))
+func SlogHooks(logger *slog.Logger) Hooks {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &slogHooks{
+		logger: logger,
+	}
+}
+
+// slogHooks is a struct that implements the [Hooks] interface by logging to a [slog.Logger].
+// This struct is used internally and should not be used directly.
+// To create one, use the [SlogHooks] function instead.
+type slogHooks struct {
+	logger *slog.Logger
+}
+
+func (s *slogHooks) OnHoard(event AuditEvent) {
+	s.log("hoard: hoarded", auditAttrs(event)...)
+}
+
+func (s *slogHooks) OnReplace(event AuditEvent) {
+	s.log("hoard: replaced", append(auditAttrs(event), slog.String("previous_caller", event.PreviousCaller))...)
+}
+
+func (s *slogHooks) OnEquip(event EquipEvent) {
+	s.log("hoard: equipped",
+		slog.String("inventory", event.Inventory),
+		slog.String("type", event.Type),
+		slog.String("name", event.Name),
+		slog.Bool("hit", event.Hit),
+		slog.String("resolution", event.Resolution.String()),
+		slog.Duration("latency", event.Latency),
+	)
+}
+
+func (s *slogHooks) OnMerge(event MergeEvent) {
+	s.log("hoard: merged",
+		slog.Int("inventories", event.Inventories),
+		slog.Int("items", event.Items),
+		slog.Duration("duration", event.Duration),
+	)
+}
+
+// log is a method that logs the given message with the given attributes at debug level.
+func (s *slogHooks) log(msg string, attrs ...slog.Attr) {
+	ctx := context.Background()
+
+	// skip building the record altogether if debug logs are disabled
+	if !s.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	s.logger.LogAttrs(ctx, slog.LevelDebug, msg, attrs...)
+}
+
+// auditAttrs is a function that returns the attributes describing the given [AuditEvent].
+func auditAttrs(event AuditEvent) []slog.Attr {
+	return []slog.Attr{
+		slog.String("inventory", event.Inventory),
+		slog.String("type", event.Type),
+		slog.String("name", event.Name),
+		slog.Uint64("version", event.Version),
+		slog.String("caller", event.Caller),
+	}
+}
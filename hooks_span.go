@@ -0,0 +1,107 @@
+package hoard
+
+import (
+	"strconv"
+	"time"
+)
+
+// Tracer is an interface that bridges the [SpanHooks] to a tracing library.
+// Example bridge to OpenTelemetry:
+//
+//	type otelTracer struct {
+//		tracer trace.Tracer
+//	}
+//
+//	func (t otelTracer) StartSpan(name string, start time.Time, attributes map[string]string) hoard.Span {
+//		kvs := make([]attribute.KeyValue, 0, len(attributes))
+//		for k, v := range attributes {
+//			kvs = append(kvs, attribute.String(k, v))
+//		}
+//
+//		_, span := t.tracer.Start(context.Background(), name, trace.WithTimestamp(start), trace.WithAttributes(kvs...))
+//
+//		return otelSpan{span}
+//	}
+//
+//	type otelSpan struct {
+//		span trace.Span
+//	}
+//
+//	func (s otelSpan) End(end time.Time) {
+//		s.span.End(trace.WithTimestamp(end))
+//	}
+type Tracer interface {
+
+	// StartSpan starts a span with the given name and attributes at the given time.
+	StartSpan(name string, start time.Time, attributes map[string]string) Span
+}
+
+// Span is an interface that describes a span started by a [Tracer].
+type Span interface {
+
+	// End ends the span at the given time.
+	End(end time.Time)
+}
+
+// SpanHooks is a function that returns [Hooks] recording a span through the given [Tracer] for every hoard, replacement, equip and merge.
+// Equips and merges are recorded with the time they took, while hoards and replacements are recorded as instant spans.
+// The spans are named "hoard.hoard", "hoard.replace", "hoard.equip" and "hoard.merge", and their attributes are prefixed with "hoard.".
+// Example usage:
+//
+//	Hoard(HoardOptions{}.WithHooks(SpanHooks(otelTracer{tracer})))
+func SpanHooks(tracer Tracer) Hooks {
+	return &spanHooks{
+		tracer: tracer,
+	}
+}
+
+// spanHooks is a struct that implements the [Hooks] interface by recording spans through a [Tracer].
+// This struct is used internally and should not be used directly.
+// To create one, use the [SpanHooks] function instead.
+type spanHooks struct {
+	tracer Tracer
+}
+
+func (s *spanHooks) OnHoard(event AuditEvent) {
+	s.record("hoard.hoard", event.At, 0, auditAttributes(event))
+}
+
+func (s *spanHooks) OnReplace(event AuditEvent) {
+	attributes := auditAttributes(event)
+	attributes["hoard.previous_caller"] = event.PreviousCaller
+
+	s.record("hoard.replace", event.At, 0, attributes)
+}
+
+func (s *spanHooks) OnEquip(event EquipEvent) {
+	s.record("hoard.equip", event.At, event.Latency, map[string]string{
+		"hoard.inventory":  event.Inventory,
+		"hoard.type":       event.Type,
+		"hoard.name":       event.Name,
+		"hoard.hit":        strconv.FormatBool(event.Hit),
+		"hoard.resolution": event.Resolution.String(),
+	})
+}
+
+func (s *spanHooks) OnMerge(event MergeEvent) {
+	s.record("hoard.merge", event.At, event.Duration, map[string]string{
+		"hoard.inventories": strconv.Itoa(event.Inventories),
+		"hoard.items":       strconv.Itoa(event.Items),
+	})
+}
+
+// record is a method that records a span with the given name, start time, duration and attributes.
+func (s *spanHooks) record(name string, start time.Time, duration time.Duration, attributes map[string]string) {
+	s.tracer.StartSpan(name, start, attributes).End(start.Add(duration))
+}
+
+// auditAttributes is a function that returns the span attributes describing the given [AuditEvent].
+func auditAttributes(event AuditEvent) map[string]string {
+	return map[string]string{
+		"hoard.inventory": event.Inventory,
+		"hoard.type":      event.Type,
+		"hoard.name":      event.Name,
+		"hoard.version":   strconv.FormatUint(event.Version, 10),
+		"hoard.caller":    event.Caller,
+	}
+}
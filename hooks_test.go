@@ -0,0 +1,286 @@
+package hoard
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// hooksRecorder is a Hooks collecting the name of every called hook and every received EquipEvent and MergeEvent.
+type hooksRecorder struct {
+	calls  []string
+	equips []EquipEvent
+	merges []MergeEvent
+	mu     sync.Mutex
+}
+
+func (r *hooksRecorder) OnHoard(event AuditEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, "hoard "+event.Type)
+}
+
+func (r *hooksRecorder) OnReplace(event AuditEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, "replace "+event.Type)
+}
+
+func (r *hooksRecorder) OnEquip(event EquipEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, "equip "+event.Type)
+	r.equips = append(r.equips, event)
+}
+
+func (r *hooksRecorder) OnMerge(event MergeEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, "merge")
+	r.merges = append(r.merges, event)
+}
+
+func (s *suiteTest) TestHooks() {
+	recorder := &hooksRecorder{}
+
+	h := factory()
+
+	opt := HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h).WithHooks(recorder)
+
+	Hoard(opt, "test")
+	Hoard(opt, "test2", 1)
+	Discard[int](nil, h)
+
+	_ = EquipDefault[string](h)
+
+	require.Equal(s.T(), []string{
+		"hoard string",
+		"merge",
+		"replace string",
+		"hoard int",
+		"merge",
+		"equip string",
+	}, recorder.calls)
+
+	require.Len(s.T(), recorder.merges, 2)
+	require.Equal(s.T(), 1, recorder.merges[0].Items)
+	require.Equal(s.T(), 2, recorder.merges[1].Items)
+	require.Equal(s.T(), 1, recorder.merges[1].Inventories)
+}
+
+func (s *suiteTest) TestHooks_OnEquip() {
+	tests := []struct {
+		name           string
+		givenEquip     func(h Hoarder)
+		wantHit        bool
+		wantResolution ResolutionKind
+		wantInventory  string
+		wantName       string
+	}{
+		{
+			name: "should report an exact hit",
+			givenEquip: func(h Hoarder) {
+				_ = EquipDefault[*TestFooImpl](h)
+			},
+			wantHit:        true,
+			wantResolution: ResolutionExact,
+			wantInventory:  "default",
+			wantName:       "",
+		},
+		{
+			name: "should report an alias hit",
+			givenEquip: func(h Hoarder) {
+				_, _ = EquipVersioned[TestFooer](EquipOptions{}.WithCustomInventoryName("test").WithCustomItemName("foo"), h)
+			},
+			wantHit:        true,
+			wantResolution: ResolutionAlias,
+			wantInventory:  "test",
+			wantName:       "foo",
+		},
+		{
+			name: "should report an interface scan hit",
+			givenEquip: func(h Hoarder) {
+				_ = EquipDefault[TestFooer](h)
+			},
+			wantHit:        true,
+			wantResolution: ResolutionInterfaceScan,
+			wantInventory:  "default",
+			wantName:       "",
+		},
+		{
+			name: "should report a miss",
+			givenEquip: func(h Hoarder) {
+				require.Panics(s.T(), func() {
+					_ = EquipWithOption[string](EquipOptions{}.WithCustomInventoryName("missing"), h)
+				})
+			},
+			wantHit:        false,
+			wantResolution: ResolutionMiss,
+			wantInventory:  "missing",
+			wantName:       "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			recorder := &hooksRecorder{}
+
+			h := factory()
+
+			Hoard(
+				HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h).WithHooks(recorder),
+				&TestFooImpl{},
+				UseInventory("test").Put(RememberAs(&TestFooImpl{}, "foo")),
+			)
+
+			tt.givenEquip(h)
+
+			require.Len(s.T(), recorder.equips, 1)
+
+			got := recorder.equips[0]
+
+			require.Equal(s.T(), tt.wantHit, got.Hit)
+			require.Equal(s.T(), tt.wantResolution, got.Resolution)
+			require.Equal(s.T(), tt.wantInventory, got.Inventory)
+			require.Equal(s.T(), tt.wantName, got.Name)
+			require.False(s.T(), got.At.IsZero())
+		})
+	}
+}
+
+func (s *suiteTest) TestJoinHooks() {
+	first := &hooksRecorder{}
+	second := &hooksRecorder{}
+
+	h := factory()
+
+	Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h).WithHooks(JoinHooks(first, nil, second)), "test")
+
+	require.Equal(s.T(), []string{"hoard string", "merge"}, first.calls)
+	require.Equal(s.T(), first.calls, second.calls)
+}
+
+func (s *suiteTest) TestSlogHooks() {
+	tests := []struct {
+		name      string
+		level     slog.Level
+		wantLines []string
+	}{
+		{
+			name:      "should log at debug level",
+			level:     slog.LevelDebug,
+			wantLines: []string{"hoard: hoarded", "hoard: merged", "hoard: replaced", "hoard: merged", "hoard: equipped"},
+		},
+		{
+			name:      "should not log if debug level is disabled",
+			level:     slog.LevelInfo,
+			wantLines: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			buf := &bytes.Buffer{}
+
+			logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: tt.level}))
+
+			h := factory()
+
+			opt := HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h).WithHooks(SlogHooks(logger))
+
+			Hoard(opt, "test")
+			Hoard(opt, "test2")
+
+			_ = EquipDefault[string](h)
+
+			gotLines := []string{}
+			for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+				if line == "" {
+					continue
+				}
+
+				entry := map[string]interface{}{}
+				require.NoError(s.T(), json.Unmarshal([]byte(line), &entry))
+				require.Equal(s.T(), "DEBUG", entry["level"])
+
+				gotLines = append(gotLines, entry["msg"].(string))
+
+				if entry["msg"] == "hoard: equipped" {
+					require.Equal(s.T(), "string", entry["type"])
+					require.Equal(s.T(), true, entry["hit"])
+					require.Equal(s.T(), "exact", entry["resolution"])
+				}
+			}
+
+			require.Equal(s.T(), tt.wantLines, gotLines)
+		})
+	}
+}
+
+// spanRecorder is a Tracer collecting every ended span.
+type spanRecorder struct {
+	spans []recordedSpan
+	mu    sync.Mutex
+}
+
+type recordedSpan struct {
+	name       string
+	start      time.Time
+	end        time.Time
+	attributes map[string]string
+	recorder   *spanRecorder
+}
+
+func (r *spanRecorder) StartSpan(name string, start time.Time, attributes map[string]string) Span {
+	return &recordedSpan{name: name, start: start, attributes: attributes, recorder: r}
+}
+
+func (s *recordedSpan) End(end time.Time) {
+	s.end = end
+
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+
+	s.recorder.spans = append(s.recorder.spans, *s)
+}
+
+func (s *suiteTest) TestSpanHooks() {
+	recorder := &spanRecorder{}
+
+	h := factory()
+
+	opt := HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h).WithHooks(SpanHooks(recorder))
+
+	Hoard(opt, "test")
+	Hoard(opt, "test2")
+
+	_ = EquipDefault[string](h)
+
+	gotNames := []string{}
+	for _, span := range recorder.spans {
+		gotNames = append(gotNames, span.name)
+
+		require.False(s.T(), span.end.Before(span.start))
+	}
+
+	require.Equal(s.T(), []string{"hoard.hoard", "hoard.merge", "hoard.replace", "hoard.merge", "hoard.equip"}, gotNames)
+
+	require.True(s.T(), strings.Contains(recorder.spans[2].attributes["hoard.caller"], "hooks_test.go:"))
+	require.Equal(s.T(), recorder.spans[0].attributes["hoard.caller"], recorder.spans[2].attributes["hoard.previous_caller"])
+	require.Equal(s.T(), map[string]string{
+		"hoard.inventory":  "default",
+		"hoard.type":       "string",
+		"hoard.name":       "",
+		"hoard.hit":        "true",
+		"hoard.resolution": "exact",
+	}, recorder.spans[4].attributes)
+}
@@ -0,0 +1,198 @@
+package hoard
+
+import (
+	"reflect"
+)
+
+// inventoryConfig is a struct that holds the configuration to be used when calling the [UseInventory] function.
+// This struct is used internally and should not be used directly.
+// To specify the desired configuration, use an [InventoryOption] when calling the [UseInventory] function instead.
+type inventoryConfig struct {
+
+	// parents holds the names of the inventories the inventory inherits from, in resolution order.
+	parents []string
+
+	// shadowMode is the [ShadowMode] of the things hoarded into the inventory.
+	shadowMode ShadowMode
+}
+
+// InventoryOption is a type that holds an option to be used when calling the [UseInventory] function.
+// To create an [InventoryOption], use the [InheritsFrom] or [WithShadowMode] functions.
+type InventoryOption struct {
+	f func(*inventoryConfig) *inventoryConfig
+}
+
+// apply is a method that applies a side effect to the [inventoryConfig] struct using the function stored in the [InventoryOption] struct.
+func (o InventoryOption) apply(cfg *inventoryConfig) *inventoryConfig {
+	if o.f == nil {
+		return cfg
+	}
+
+	return o.f(cfg)
+}
+
+// InheritsFrom is a function that returns an [InventoryOption] declaring the inventories the [Inventory] inherits from, in resolution order.
+// A thing that cannot be equipped from the [Inventory] is then resolved from each of the given inventories in turn, and from the inventories they inherit from,
+// the same way as the [EquipWithOption] function does, hence its exact name, its alias and the implementations of an interface
+// are all looked up in an [Inventory] before moving on to the next one.
+// Passing an empty string inherits from the default [Inventory].
+//
+// The inheritance belongs to the [Inventory] once hoarded: hoarding the [Inventory] again with other parents replaces them, while hoarding it without any keeps them.
+// Functions changing things, such as [Discard], [CompareAndSwap] and [Rollback], only change the [Inventory] itself, never the ones it inherits from.
+//
+// Example usage:
+//
+//	Hoard(nil,
+//		UseInventory("prod").Put(RememberAs(prodDB, "primary")),
+//		UseInventory("staging", InheritsFrom("prod")).Put(RememberAs(stagingCache, "")),
+//	)
+//
+//	db := EquipWithOption[*sql.DB](EquipOptions{}.WithCustomInventoryName("staging").WithCustomItemName("primary"))
+func InheritsFrom(parents ...string) InventoryOption {
+	return InventoryOption{f: func(cfg *inventoryConfig) *inventoryConfig {
+		cfg.parents = make([]string, 0, len(parents))
+		for _, parent := range parents {
+			cfg.parents = append(cfg.parents, getCustomInventoryName(parent))
+		}
+
+		return cfg
+	}}
+}
+
+// WithFallbackInventories is a method that sets the [fallbackInventoryNames] field in the [equipConfig] struct to the given value.
+// The method returns a new [EquipOptions] with the updated configuration.
+// Typical usage of this method is to look the desired thing up in other inventories, in the given order, if it cannot be equipped from the specified inventory.
+// Each inventory is walked along with the inventories it inherits from, refer to the [InheritsFrom] function.
+// Passing an empty string falls back to the default [Inventory].
+//
+// The fallback inventories are honoured by the functions equipping things, such as [EquipWithOption], [EquipOr], [EquipWait], [EquipVersioned], [Ref], [Watch] and [Need],
+// while the functions changing things, such as [Discard], [CompareAndSwap] and [Rollback], and the [History] function only use the specified inventory.
+//
+// Example usage:
+//
+//	EquipWithOption[*sql.DB](EquipOptions{}.WithCustomInventoryName("tenant-42").WithFallbackInventories("shared", ""))
+func (h EquipOptions) WithFallbackInventories(customInventoryNames ...string) EquipOptions {
+	return append(h, newFuncEquipOptions(func(opt *equipConfig) *equipConfig {
+		opt.fallbackInventoryNames = customInventoryNames
+		return opt
+	}))
+}
+
+// EquipOr is a function that returns the requested thing from the specified [Inventory], or the given fallback thing if it is not hoarded.
+// The thing is resolved the same way as the [EquipWithOption] function does, including from the inventories inherited or given as fallbacks,
+// but the function never panics, including when several things match the selector given with the [EquipOptions.WithSelector] method.
+//
+// To specify custom [Item] name or custom [Inventory] name, use the [EquipOptions] when calling the [EquipOr] function.
+//
+// To specify a custom [Hoarder] to be used, pass the custom [Hoarder] as an argument when calling the [EquipOr] function.
+//
+// The [EquipOr] function is thread-safe.
+//
+// Example usage:
+//
+//	timeout := EquipOr[time.Duration](EquipOptions{}.WithCustomItemName("timeout"), 5*time.Second)
+func EquipOr[T any](opt EquipOptions, fallback T, customHoarder ...Hoarder) T {
+	cfg := defaultEquipConfig
+
+	for _, f := range opt {
+		f.apply(&cfg)
+	}
+
+	hoarder := pickHoarder(customHoarder...)
+
+	if cfg.selector != "" {
+		v, err := equipSelected(hoarder, reflect.TypeFor[T](), cfg.inventoryNames(), cfg.customItemName, cfg.selector)
+		if err != nil {
+			return fallback
+		}
+
+		return v.use().(T)
+	}
+
+	v, _ := equipAcross(hoarder, reflect.TypeFor[T](), cfg.inventoryNames(), cfg.customItemName)
+	if v == nil {
+		return fallback
+	}
+
+	return v.use().(T)
+}
+
+// inventoryNames is a method that returns the name of the specified inventory followed by the names of the fallback inventories, in resolution order.
+// Each inventory is followed by its parents in the hierarchy if they are walked up, and an inventory appearing twice is only kept the first time.
+func (cfg *equipConfig) inventoryNames() []string {
+	if cfg.walkUpInventories || len(cfg.fallbackInventoryNames) > 0 {
+		return cfg.walkInventoryNames()
+	}
+
+	// most lookups neither fall back nor walk up, their single inventory is returned without walking the other ones
+	return []string{getCustomInventoryName(cfg.customInventoryName)}
+}
+
+// walkInventoryNames is a method that returns the names of the inventories as the inventoryNames method does, walking each of them.
+func (cfg *equipConfig) walkInventoryNames() []string {
+	names := make([]string, 0, 1+len(cfg.fallbackInventoryNames))
+	seen := make(map[string]bool)
+
+	for _, name := range append([]string{cfg.customInventoryName}, cfg.fallbackInventoryNames...) {
+		chain := []string{name}
+		if cfg.walkUpInventories {
+			chain = append(chain, parentInventoryNames(name)...)
+		}
+
+		for _, name := range chain {
+			if seen[getCustomInventoryName(name)] {
+				continue
+			}
+
+			seen[getCustomInventoryName(name)] = true
+			names = append(names, getCustomInventoryName(name))
+		}
+	}
+
+	return names
+}
+
+// equipAcross is a function that resolves the requested thing from the first of the given inventories holding it, the same way as the equip method of the given [Hoarder] does.
+// The lookup is reported once, for the [Inventory] the thing is found in, or for the first [Inventory] if none holds it.
+func equipAcross(h Hoarder, typeOfThing reflect.Type, inventoryNames []string, itemName string) (Item, uint64) {
+	if len(inventoryNames) > 1 {
+		for _, inventoryName := range inventoryNames {
+			if v, _ := h.resolve(typeOfThing, inventoryName, itemName); v == nil {
+				continue
+			}
+
+			// the hoarder may have changed since the thing was resolved
+			if v, version := h.equip(typeOfThing, inventoryName, itemName); v != nil {
+				return v, version
+			}
+		}
+	}
+
+	return h.equip(typeOfThing, inventoryNames[0], itemName)
+}
+
+// resolveAcross is a function that resolves the requested thing from the first of the given inventories holding it, the same way as the resolve method of the given [Hoarder] does.
+func resolveAcross(h Hoarder, typeOfThing reflect.Type, inventoryNames []string, itemName string) (Item, uint64) {
+	for _, inventoryName := range inventoryNames {
+		if v, version := h.resolve(typeOfThing, inventoryName, itemName); v != nil {
+			return v, version
+		}
+	}
+
+	return nil, 0
+}
+
+// observeAcross is a function that registers the given function to be called whenever any of the given inventories changes.
+// The function returns a function that unregisters the given function.
+func observeAcross(h Hoarder, inventoryNames []string, notify func()) func() {
+	releases := make([]func(), 0, len(inventoryNames))
+	for _, inventoryName := range inventoryNames {
+		releases = append(releases, h.observe(inventoryName, notify))
+	}
+
+	return func() {
+		for _, release := range releases {
+			release()
+		}
+	}
+}
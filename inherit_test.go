@@ -0,0 +1,194 @@
+package hoard
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testInheritDB struct {
+	Name string
+}
+
+func (db *testInheritDB) name() string {
+	return db.Name
+}
+
+type testInheritNamer interface {
+	name() string
+}
+
+func (s *suiteTest) TestInheritsFrom() {
+	opt := HoardOptions{}.ShouldReplaceGlobal(false)
+
+	h := Hoard(opt,
+		"root",
+		UseInventory("base").Put(RememberAs(&testInheritDB{Name: "base"}, "primary")).Put(RememberAs(1, "")).Put(RememberAs(true, "")),
+		UseInventory("prod", InheritsFrom("base")).Put(RememberAs(&testInheritDB{Name: "prod"}, "primary")),
+		UseInventory("staging", InheritsFrom("prod", "")).Put(RememberAs(2.5, "")),
+		UseInventory("loop", InheritsFrom("loop", "staging")),
+	)
+
+	tests := []struct {
+		name  string
+		equip func() interface{}
+		want  interface{}
+	}{
+		{
+			name: "should equip from the inventory itself first",
+			equip: func() interface{} {
+				return EquipWithOption[float64](EquipOptions{}.WithCustomInventoryName("staging"), h)
+			},
+			want: 2.5,
+		},
+		{
+			name: "should equip from the parent",
+			equip: func() interface{} {
+				return EquipWithOption[*testInheritDB](EquipOptions{}.WithCustomInventoryName("staging").WithCustomItemName("primary"), h).Name
+			},
+			want: "prod",
+		},
+		{
+			name: "should equip an interface from the nearest inventory holding an implementation",
+			equip: func() interface{} {
+				return EquipWithOption[testInheritNamer](EquipOptions{}.WithCustomInventoryName("staging"), h).name()
+			},
+			want: "prod",
+		},
+		{
+			name: "should equip from the parent of the parent",
+			equip: func() interface{} {
+				return EquipWithOption[int](EquipOptions{}.WithCustomInventoryName("staging"), h)
+			},
+			want: 1,
+		},
+		{
+			name: "should equip from the default inventory if inherited",
+			equip: func() interface{} {
+				return EquipWithOption[string](EquipOptions{}.WithCustomInventoryName("staging"), h)
+			},
+			want: "root",
+		},
+		{
+			name: "should equip through an inventory inheriting from itself",
+			equip: func() interface{} {
+				return EquipWithOption[string](EquipOptions{}.WithCustomInventoryName("loop"), h)
+			},
+			want: "root",
+		},
+		{
+			name: "should equip an exact key from the parent",
+			equip: func() interface{} {
+				return NewKey[*testInheritDB]("staging", "primary").Equip(h).Name
+			},
+			want: "prod",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			require.Equal(s.T(), tt.want, tt.equip())
+		})
+	}
+
+	require.Panics(s.T(), func() {
+		EquipWithOption[string](EquipOptions{}.WithCustomInventoryName("prod"), h)
+	})
+
+	// hoarding the inventory again keeps its parents unless others are declared
+	Hoard(opt.WithCustomHoarder(h), UseInventory("prod").Put(RememberAs(3, "")))
+	require.Equal(s.T(), 3, EquipWithOption[int](EquipOptions{}.WithCustomInventoryName("prod"), h))
+	require.True(s.T(), EquipWithOption[bool](EquipOptions{}.WithCustomInventoryName("prod"), h.Clone()))
+
+	Hoard(opt.WithCustomHoarder(h), UseInventory("staging", InheritsFrom()))
+	require.Panics(s.T(), func() {
+		EquipWithOption[int](EquipOptions{}.WithCustomInventoryName("staging"), h)
+	})
+
+	// discarding from an inventory never discards from its parents
+	Discard[*testInheritDB](EquipOptions{}.WithCustomInventoryName("prod").WithCustomItemName("primary"), h)
+	require.Equal(s.T(), "base", EquipWithOption[*testInheritDB](EquipOptions{}.WithCustomInventoryName("prod").WithCustomItemName("primary"), h).Name)
+}
+
+func (s *suiteTest) TestInheritsFrom_notifies() {
+	opt := HoardOptions{}.ShouldReplaceGlobal(false)
+
+	h := Hoard(opt, UseInventory("staging", InheritsFrom("prod")))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	done := make(chan int)
+	go func() {
+		v, err := EquipWait[int](ctx, EquipOptions{}.WithCustomInventoryName("staging"), h)
+		s.NoError(err)
+
+		done <- v
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	Hoard(opt.WithCustomHoarder(h), UseInventory("prod").Put(RememberAs(42, "")))
+
+	require.Equal(s.T(), 42, <-done)
+}
+
+func (s *suiteTest) TestWithFallbackInventories() {
+	opt := HoardOptions{}.ShouldReplaceGlobal(false)
+
+	h := Hoard(opt,
+		"root",
+		UseInventory("shared").Put(RememberAs(1, "")).Put(RememberAs(&testInheritDB{Name: "shared"}, "")),
+		UseInventory("tenant").Put(RememberAs(&testInheritDB{Name: "tenant"}, "")),
+	)
+
+	options := EquipOptions{}.WithCustomInventoryName("tenant").WithFallbackInventories("shared", "")
+
+	require.Equal(s.T(), "tenant", EquipWithOption[*testInheritDB](options, h).Name)
+	require.Equal(s.T(), 1, EquipWithOption[int](options, h))
+	require.Equal(s.T(), "root", EquipWithOption[string](options, h))
+	require.Panics(s.T(), func() {
+		EquipWithOption[float64](options, h)
+	})
+
+	v, version := EquipVersioned[int](options, h)
+	require.Equal(s.T(), 1, v)
+	require.Equal(s.T(), uint64(1), version)
+
+	ref := Ref[int](options, h)
+	defer ref.Release()
+
+	require.Equal(s.T(), 1, ref.Get())
+
+	Hoard(opt.WithCustomHoarder(h), UseInventory("tenant").Put(RememberAs(2, "")))
+	require.Equal(s.T(), 2, ref.Get())
+
+	h.Require(Need[int](options), Need[string](options))
+	require.NoError(s.T(), h.Verify())
+
+	h.Require(Need[string](EquipOptions{}.WithCustomInventoryName("tenant")))
+	require.ErrorIs(s.T(), h.Verify(), ErrRequirementNotMet)
+
+	// the lookup is reported once, for the inventory the thing is found in
+	var stats []EquipStats
+	for _, e := range h.Stats().Equips {
+		if e.Type == "string" {
+			stats = append(stats, e)
+		}
+	}
+
+	require.Equal(s.T(), []EquipStats{
+		{Inventory: "default", Type: "string", Equips: 1},
+	}, stats)
+}
+
+func (s *suiteTest) TestEquipOr() {
+	opt := HoardOptions{}.ShouldReplaceGlobal(false)
+
+	h := Hoard(opt, 1, UseInventory("shared").Put(RememberAs("shared", "")))
+
+	require.Equal(s.T(), 1, EquipOr(nil, 2, h))
+	require.Equal(s.T(), 2.5, EquipOr(nil, 2.5, h))
+	require.Equal(s.T(), "fallback", EquipOr(EquipOptions{}.WithCustomInventoryName("tenant"), "fallback", h))
+	require.Equal(s.T(), "shared", EquipOr(EquipOptions{}.WithCustomInventoryName("tenant").WithFallbackInventories("shared"), "fallback", h))
+}
@@ -0,0 +1,479 @@
+// Package callsite collects, from the syntax trees of a type-checked package, the things hoarded and equipped by its calls to the hoard package.
+// It is shared by the hoardcheck analyzer and the hoarddoc command.
+package callsite
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// HoardPath is the import path of the hoard package.
+const HoardPath = "github.com/oopchi/hoard"
+
+// Site is a struct that describes a thing hoarded or equipped by a call site.
+// The struct is gob-encodable, so that it can be part of an analysis fact.
+type Site struct {
+
+	// Func is the hoard function or method called, such as "Hoard", "RememberAs" or "EquipWithOption".
+	Func string
+
+	// Package is the import path of the package of the call site.
+	Package string
+
+	// Type is the type of the thing, as printed by the reflect package.
+	Type string
+
+	// Key is the name the thing is stored under, as computed by the getThingName function of the hoard package.
+	Key string
+
+	// PkgPath and TypeName identify the named type of the thing, if any.
+	PkgPath  string
+	TypeName string
+
+	// Pointer reports whether the thing is a pointer to the named type.
+	Pointer bool
+
+	// Interface reports whether the type of the thing is an interface.
+	Interface bool
+
+	// Inventory is the inventory name, as given to the hoard package, or an empty string for the default inventory.
+	Inventory string
+
+	// Name is the custom item name, or an empty string if none.
+	Name string
+
+	// DynamicType, DynamicInventory and DynamicName report whether the type, inventory name or item name is unknown at compile time.
+	DynamicType      bool
+	DynamicInventory bool
+	DynamicName      bool
+
+	// Inherits reports whether the call site declares an inventory inheriting from others, with the [hoard.InheritsFrom] option.
+	// Any thing may then be equipped from the inventory, while none is put into the default inventory.
+	Inherits bool
+
+	// Position is the call site, formatted as "package/file:line".
+	Position string
+
+	// pos is the call site, only known within the collected package.
+	pos token.Pos
+}
+
+// Pos is a method that returns the position of the call site, only valid within the collected package.
+func (s Site) Pos() token.Pos {
+	return s.pos
+}
+
+// Describe is a method that returns a human-readable description of the thing, in the same format as the hoard package.
+func (s Site) Describe() string {
+	typ := s.Type
+
+	if s.Name != "" {
+		typ += " named " + strconv.Quote(s.Name)
+	}
+
+	return typ + " in inventory " + InventoryName(s.Inventory)
+}
+
+// InventoryName is a function that returns the display name of the given inventory name.
+func InventoryName(name string) string {
+	if name == "" {
+		return "default"
+	}
+
+	return name
+}
+
+// Collector is a struct that collects the things hoarded and equipped by the calls of a package.
+// To create a new collector, use the [NewCollector] function instead.
+type Collector struct {
+
+	// Hoarded holds the things hoarded by the visited calls.
+	Hoarded []Site
+
+	// Equipped holds the things equipped by the visited calls.
+	Equipped []Site
+
+	fset *token.FileSet
+	pkg  *types.Package
+	info *types.Info
+
+	// report is called for every thing of a function type, which is never hoarded.
+	report func(pos token.Pos, format string, args ...interface{})
+
+	// consumed holds the item expressions already collected as part of an inventory.
+	consumed map[ast.Expr]bool
+}
+
+// NewCollector is a function that returns a new [Collector] of the given type-checked package.
+// The given function, if any, is called for every thing of a function type given to the hoard package, which is never hoarded.
+func NewCollector(fset *token.FileSet, pkg *types.Package, info *types.Info, report func(pos token.Pos, format string, args ...interface{})) *Collector {
+	if report == nil {
+		report = func(token.Pos, string, ...interface{}) {}
+	}
+
+	return &Collector{
+		Hoarded:  make([]Site, 0),
+		Equipped: make([]Site, 0),
+		fset:     fset,
+		pkg:      pkg,
+		info:     info,
+		report:   report,
+		consumed: make(map[ast.Expr]bool),
+	}
+}
+
+// Visit is a method that collects the things hoarded or equipped by the given call.
+// The calls must be visited in source order, with a call visited before the calls among its arguments.
+func (c *Collector) Visit(call *ast.CallExpr) {
+	callee := c.callee(call)
+
+	switch callee {
+	case "":
+		return
+	case "Hoard":
+		if len(call.Args) > 1 {
+			c.hoardAll(call, callee, call.Args[1:])
+		}
+	case "Tx.Put":
+		c.hoardAll(call, callee, call.Args)
+	case "RememberAs", "Reveal", "Label":
+		if !c.consumed[call] {
+			c.hoardItem(call, callee, "", false)
+		}
+	case "Inventory.Put", "Inventory.PutIfAbsent":
+		name, dynamic := c.inventoryOf(call.Fun.(*ast.SelectorExpr).X)
+		c.hoardItem(call.Args[0], callee, name, dynamic)
+	case "UseInventory":
+		// the things of the inventories inherited from are unknown
+		if slices.ContainsFunc(call.Args[1:], func(arg ast.Expr) bool {
+			opt, ok := ast.Unparen(arg).(*ast.CallExpr)
+			return !ok || c.callee(opt) != "WithShadowMode"
+		}) {
+			s := c.dynamicSite(call, callee)
+			s.Inventory, s.DynamicInventory = c.constString(call.Args[0])
+			s.Inherits = true
+			c.Hoarded = append(c.Hoarded, s)
+		}
+	}
+
+	typeArg := c.typeArg(call)
+	if typeArg == nil {
+		return
+	}
+
+	if isFunc(typeArg) {
+		c.report(call.Pos(), "hoard: cannot use function type %s with %s, it is never hoarded", c.typeString(typeArg), callee)
+		return
+	}
+
+	var opt ast.Expr
+	switch callee {
+	case "EquipDefault":
+	case "EquipWithOption", "EquipVersioned", "EquipOr", "EquipAll", "Ref", "Need":
+		opt = call.Args[0]
+	case "EquipWait":
+		opt = call.Args[1]
+	default:
+		return
+	}
+
+	s := c.newSite(call, callee, typeArg)
+	c.equipOptions(opt, &s)
+
+	c.Equipped = append(c.Equipped, s)
+}
+
+// hoardAll is a method that collects the things given to the [hoard.Hoard] function or the [hoard.Tx.Put] method.
+func (c *Collector) hoardAll(call *ast.CallExpr, callee string, args []ast.Expr) {
+	for i, arg := range args {
+		// the things of a spread slice are unknown
+		if call.Ellipsis.IsValid() && i == len(args)-1 {
+			c.Hoarded = append(c.Hoarded, c.dynamicSite(arg, callee))
+			continue
+		}
+
+		typ := c.info.TypeOf(arg)
+		if typ == nil || isNil(typ) {
+			continue
+		}
+
+		if isHoardType(typ, "Item") || isHoardType(typ, "Inventory") {
+			// the item or inventory is collected on its own when created in place
+			if argCall, ok := ast.Unparen(arg).(*ast.CallExpr); ok && c.callee(argCall) != "" {
+				continue
+			}
+
+			c.Hoarded = append(c.Hoarded, c.dynamicSite(arg, callee))
+			continue
+		}
+
+		c.hoardThing(arg, callee, types.Default(typ), "", false, "", false)
+	}
+}
+
+// hoardItem is a method that collects the thing wrapped by the given [hoard.Item] expression, put in the given inventory.
+func (c *Collector) hoardItem(expr ast.Expr, callee, inventory string, dynamicInventory bool) {
+	expr = ast.Unparen(expr)
+	c.consumed[expr] = true
+
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		s := c.dynamicSite(expr, callee)
+		s.Inventory, s.DynamicInventory = inventory, dynamicInventory
+		c.Hoarded = append(c.Hoarded, s)
+		return
+	}
+
+	switch c.callee(call) {
+	case "RememberAs":
+		name, dynamicName := c.constString(call.Args[1])
+		c.hoardThing(call.Args[0], callee, c.info.TypeOf(call.Args[0]), inventory, dynamicInventory, name, dynamicName)
+	case "Reveal", "Label":
+		typ := c.info.TypeOf(call.Args[0])
+		if typ != nil && isHoardType(typ, "Item") {
+			c.hoardItem(call.Args[0], callee, inventory, dynamicInventory)
+			return
+		}
+
+		c.hoardThing(call.Args[0], callee, typ, inventory, dynamicInventory, "", false)
+	default:
+		s := c.dynamicSite(expr, callee)
+		s.Inventory, s.DynamicInventory = inventory, dynamicInventory
+		c.Hoarded = append(c.Hoarded, s)
+	}
+}
+
+// hoardThing is a method that collects the given thing of the given static type.
+func (c *Collector) hoardThing(expr ast.Expr, callee string, typ types.Type, inventory string, dynamicInventory bool, name string, dynamicName bool) {
+	if typ == nil || isNil(typ) {
+		return
+	}
+
+	typ = types.Default(typ)
+
+	if isFunc(typ) {
+		c.report(expr.Pos(), "hoard: cannot hoard function type %s, it is silently ignored", c.typeString(typ))
+		return
+	}
+
+	s := c.newSite(expr, callee, typ)
+	s.Inventory, s.DynamicInventory = inventory, dynamicInventory
+	s.Name, s.DynamicName = name, dynamicName
+
+	// the dynamic type of an interface value is unknown
+	if s.Interface {
+		s.DynamicType = true
+	}
+
+	c.Hoarded = append(c.Hoarded, s)
+}
+
+// dynamicSite is a method that returns a call site of which nothing is known at compile time.
+func (c *Collector) dynamicSite(expr ast.Expr, callee string) Site {
+	return Site{
+		Func:             callee,
+		Package:          c.pkg.Path(),
+		DynamicType:      true,
+		DynamicInventory: true,
+		DynamicName:      true,
+		Position:         c.position(expr.Pos()),
+		pos:              expr.Pos(),
+	}
+}
+
+// newSite is a method that returns a call site hoarding or equipping a thing of the given type through the given node.
+func (c *Collector) newSite(node ast.Node, callee string, typ types.Type) Site {
+	s := Site{
+		Func:      callee,
+		Package:   c.pkg.Path(),
+		Type:      c.typeString(typ),
+		Key:       thingKey(typ),
+		Interface: types.IsInterface(typ),
+		Position:  c.position(node.Pos()),
+		pos:       node.Pos(),
+	}
+
+	if p, ok := typ.(*types.Pointer); ok {
+		typ = p.Elem()
+		s.Pointer = true
+	}
+
+	switch typ := types.Unalias(typ).(type) {
+	case *types.Named:
+		if typ.Obj().Pkg() != nil && typ.TypeArgs().Len() == 0 {
+			s.PkgPath, s.TypeName = typ.Obj().Pkg().Path(), typ.Obj().Name()
+		}
+	case *types.Basic:
+		s.TypeName = typ.Name()
+	case *types.TypeParam:
+		// the type argument of a generic function is only known where it is instantiated
+		s.DynamicType = true
+	}
+
+	return s
+}
+
+// equipOptions is a method that sets the inventory name and item name of the given call site from the given [hoard.EquipOptions] expression.
+func (c *Collector) equipOptions(expr ast.Expr, s *Site) {
+	if expr == nil {
+		return
+	}
+
+	// the last option applied wins, hence the outermost call
+	hasInventory, hasName, hasFallback := false, false, false
+
+	// the thing may be equipped from any of the fallback, parent or matching inventories
+	defer func() {
+		s.DynamicInventory = s.DynamicInventory || hasFallback
+	}()
+
+	for {
+		expr = ast.Unparen(expr)
+
+		if call, ok := expr.(*ast.CallExpr); ok {
+			switch c.callee(call) {
+			case "EquipOptions.WithCustomInventoryName":
+				if !hasInventory {
+					s.Inventory, s.DynamicInventory = c.constString(call.Args[0])
+					hasInventory = true
+				}
+
+				expr = call.Fun.(*ast.SelectorExpr).X
+				continue
+			case "EquipOptions.WithFallbackInventories", "EquipOptions.ShouldWalkUpInventories", "EquipOptions.WithInventoryPattern":
+				hasFallback = true
+
+				expr = call.Fun.(*ast.SelectorExpr).X
+				continue
+			case "EquipOptions.WithCustomItemName":
+				if !hasName {
+					s.Name, s.DynamicName = c.constString(call.Args[0])
+					hasName = true
+				}
+
+				expr = call.Fun.(*ast.SelectorExpr).X
+				continue
+			}
+		}
+
+		if lit, ok := expr.(*ast.CompositeLit); ok && len(lit.Elts) == 0 {
+			return
+		}
+
+		if tv, ok := c.info.Types[expr]; ok && tv.IsNil() {
+			return
+		}
+
+		// the options are built elsewhere
+		s.DynamicInventory = s.DynamicInventory || !hasInventory
+		s.DynamicName = s.DynamicName || !hasName
+
+		return
+	}
+}
+
+// inventoryOf is a method that returns the name of the inventory created by the given [hoard.Inventory] expression.
+// The method reports whether the name is unknown at compile time.
+func (c *Collector) inventoryOf(expr ast.Expr) (string, bool) {
+	for {
+		call, ok := ast.Unparen(expr).(*ast.CallExpr)
+		if !ok {
+			return "", true
+		}
+
+		switch c.callee(call) {
+		case "UseInventory":
+			return c.constString(call.Args[0])
+		case "Inventory.Put", "Inventory.PutIfAbsent":
+			expr = call.Fun.(*ast.SelectorExpr).X
+		default:
+			return "", true
+		}
+	}
+}
+
+// callee is a method that returns the name of the hoard function or method called by the given call, such as "Hoard" or "Inventory.Put".
+// The method returns an empty string if the call is not to the hoard package.
+func (c *Collector) callee(call *ast.CallExpr) string {
+	fn, ok := typeutil.Callee(c.info, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != HoardPath {
+		return ""
+	}
+
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return fn.Name()
+	}
+
+	typ := recv.Type()
+	if p, ok := typ.(*types.Pointer); ok {
+		typ = p.Elem()
+	}
+
+	if named, ok := typ.(*types.Named); ok {
+		return named.Obj().Name() + "." + fn.Name()
+	}
+
+	return fn.Name()
+}
+
+// typeArg is a method that returns the first type argument of the given call, or nil if none.
+func (c *Collector) typeArg(call *ast.CallExpr) types.Type {
+	fun := ast.Unparen(call.Fun)
+
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+
+	var id *ast.Ident
+	switch f := fun.(type) {
+	case *ast.Ident:
+		id = f
+	case *ast.SelectorExpr:
+		id = f.Sel
+	default:
+		return nil
+	}
+
+	instance, ok := c.info.Instances[id]
+	if !ok || instance.TypeArgs.Len() == 0 {
+		return nil
+	}
+
+	return instance.TypeArgs.At(0)
+}
+
+// constString is a method that returns the value of the given constant string expression.
+// The method reports whether the value is unknown at compile time.
+func (c *Collector) constString(expr ast.Expr) (string, bool) {
+	tv, ok := c.info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", true
+	}
+
+	return constant.StringVal(tv.Value), false
+}
+
+// typeString is a method that returns the given type as printed by the reflect package.
+func (c *Collector) typeString(typ types.Type) string {
+	return types.TypeString(typ, func(p *types.Package) string {
+		return p.Name()
+	})
+}
+
+// position is a method that returns the given position formatted as "package/file:line".
+func (c *Collector) position(pos token.Pos) string {
+	p := c.fset.Position(pos)
+
+	return c.pkg.Path() + "/" + filepath.Base(p.Filename) + ":" + strconv.Itoa(p.Line)
+}
@@ -0,0 +1,213 @@
+package callsite
+
+import (
+	"fmt"
+	"go/types"
+	"slices"
+	"strings"
+)
+
+// Resolver is a struct that matches the things equipped by call sites with the things hoarded by others, the same way as the hoard package does at runtime.
+// To create a new resolver, use the [NewResolver] function instead.
+type Resolver struct {
+
+	// packages holds the packages the types of the call sites are resolved from, by import path.
+	packages map[string]*types.Package
+}
+
+// NewResolver is a function that returns a new [Resolver] resolving the types of the call sites from the given packages and the packages they import, transitively.
+func NewResolver(pkgs ...*types.Package) *Resolver {
+	r := &Resolver{
+		packages: make(map[string]*types.Package),
+	}
+
+	for _, pkg := range pkgs {
+		r.index(pkg)
+	}
+
+	return r
+}
+
+// index is a method that indexes the given package and the packages it imports, transitively.
+func (r *Resolver) index(pkg *types.Package) {
+	if _, ok := r.packages[pkg.Path()]; ok {
+		return
+	}
+
+	r.packages[pkg.Path()] = pkg
+
+	for _, p := range pkg.Imports() {
+		r.index(p)
+	}
+}
+
+// Missing is a method that returns why the given equipped thing cannot be found among the given hoarded things.
+// The method returns an empty string if the thing may be found.
+func (r *Resolver) Missing(e Site, hoarded []Site) string {
+	if e.DynamicType {
+		return ""
+	}
+
+	if e.Inventory != "" && !e.DynamicInventory {
+		if !slices.ContainsFunc(hoarded, func(h Site) bool {
+			return h.DynamicInventory || h.Inventory == e.Inventory
+		}) {
+			return fmt.Sprintf("inventory %q is never registered", e.Inventory)
+		}
+	}
+
+	// the things of every inventory are also put into the default inventory, but not the things they inherit
+	candidates := make([]Site, 0, len(hoarded))
+	for _, h := range hoarded {
+		if h.Inherits && !e.DynamicInventory && !h.DynamicInventory && h.Inventory != e.Inventory {
+			continue
+		}
+
+		if e.Inventory == "" || e.DynamicInventory || h.DynamicInventory || h.Inventory == e.Inventory {
+			candidates = append(candidates, h)
+		}
+	}
+
+	if e.Name != "" && !e.DynamicName {
+		if !slices.ContainsFunc(candidates, func(h Site) bool {
+			return h.DynamicName || h.Name == e.Name
+		}) {
+			return fmt.Sprintf("item name %q is never registered in inventory %s", e.Name, InventoryName(e.Inventory))
+		}
+	}
+
+	for _, h := range candidates {
+		if r.Matches(e, h) {
+			return ""
+		}
+	}
+
+	return e.Describe() + " is never hoarded"
+}
+
+// Matches is a method that reports whether the given hoarded thing may be equipped as the given equipped thing, regardless of their inventories.
+func (r *Resolver) Matches(e, h Site) bool {
+	if h.DynamicType {
+		return true
+	}
+
+	if e.Name != "" && !e.DynamicName && !h.DynamicName && h.Name != e.Name {
+		return false
+	}
+
+	if h.Key == e.Key && h.Key != "" {
+		return true
+	}
+
+	if !e.Interface {
+		return false
+	}
+
+	iface, ok := r.resolve(e)
+	if !ok {
+		return true
+	}
+
+	typ, ok := r.resolve(h)
+	if !ok {
+		return true
+	}
+
+	return types.Implements(typ, iface.Underlying().(*types.Interface))
+}
+
+// resolve is a method that returns the type of the thing of the given call site.
+// The method reports whether the type is found among the indexed packages.
+func (r *Resolver) resolve(s Site) (types.Type, bool) {
+	var typ types.Type
+
+	switch {
+	case s.TypeName == "":
+		return nil, false
+	case s.PkgPath == "":
+		obj := types.Universe.Lookup(s.TypeName)
+		if obj == nil {
+			return nil, false
+		}
+
+		typ = obj.Type()
+	default:
+		pkg, ok := r.packages[s.PkgPath]
+		if !ok {
+			return nil, false
+		}
+
+		obj, ok := pkg.Scope().Lookup(s.TypeName).(*types.TypeName)
+		if !ok {
+			return nil, false
+		}
+
+		typ = obj.Type()
+	}
+
+	if s.Pointer {
+		typ = types.NewPointer(typ)
+	}
+
+	return typ, true
+}
+
+// thingKey is a function that returns the name a thing of the given type is stored under, as computed by the getThingName function of the hoard package.
+func thingKey(typ types.Type) string {
+	prefix := ""
+
+	if p, ok := typ.(*types.Pointer); ok {
+		typ = p.Elem()
+		prefix = "*"
+	}
+
+	switch typ := types.Unalias(typ).(type) {
+	case *types.Named:
+		path := ""
+		if typ.Obj().Pkg() != nil {
+			path = typ.Obj().Pkg().Path()
+		}
+
+		name := typ.Obj().Name()
+		if typ.TypeArgs().Len() > 0 {
+			args := make([]string, 0, typ.TypeArgs().Len())
+			for i := 0; i < typ.TypeArgs().Len(); i++ {
+				args = append(args, types.TypeString(typ.TypeArgs().At(i), nil))
+			}
+
+			name += "[" + strings.Join(args, ",") + "]"
+		}
+
+		return prefix + path + name
+	case *types.Basic:
+		// aliases such as byte are printed as their original type
+		return prefix + types.Typ[typ.Kind()].Name()
+	}
+
+	return prefix
+}
+
+// isFunc is a function that reports whether the given type is a function type or a pointer to a function type.
+func isFunc(typ types.Type) bool {
+	if p, ok := typ.Underlying().(*types.Pointer); ok {
+		typ = p.Elem()
+	}
+
+	_, ok := typ.Underlying().(*types.Signature)
+
+	return ok
+}
+
+// isNil is a function that reports whether the given type is the type of the untyped nil.
+func isNil(typ types.Type) bool {
+	b, ok := typ.(*types.Basic)
+
+	return ok && b.Kind() == types.UntypedNil
+}
+
+// isHoardType is a function that reports whether the given type is the named type of the hoard package with the given name.
+func isHoardType(typ types.Type, name string) bool {
+	named, ok := types.Unalias(typ).(*types.Named)
+
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == HoardPath && named.Obj().Name() == name
+}
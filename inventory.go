@@ -33,24 +33,136 @@ type Inventory interface {
 	// Prefer using [EquipDefault] or [EquipWithOption] instead.
 	equip(name string) Item
 
+	// put adds an [Item] to the inventory, recording the given origin.
+	// Should only be used internally.
+	// Prefer using [Inventory.Put] instead.
+	put(item Item, o origin) Inventory
+
+	// putIfAbsent adds an [Item] to the inventory if it does not exist yet, recording the given origin.
+	// Should only be used internally.
+	// Prefer using [Inventory.PutIfAbsent] instead.
+	putIfAbsent(item Item, o origin) Inventory
+
+	// merge puts every [Item] of the given inventory into the inventory, keeping the origin it was recorded with.
 	merge(inventoryImpl Inventory) Inventory
 
+	// mergeSnapshot puts every [Item] of the given snapshot into the inventory, keeping the origin it was recorded with.
+	mergeSnapshot(snapshot inventorySnapshot) Inventory
+
+	// snapshot returns the latest record of each [Item] in the inventory, along with the name and the parents of the inventory.
+	snapshot() inventorySnapshot
+
+	// remove removes the [Item] with the given name from the inventory if it exists, recording the given origin.
+	// Should only be used internally.
+	// Prefer using [Discard] instead.
+	remove(name string, o origin) Inventory
+
+	// version returns the version of the slot with the given name.
+	// The version starts at 0 and is increased each time an [Item] is put into or removed from the slot.
+	// Should only be used internally.
+	// Prefer using [EquipVersioned] instead.
+	version(name string) uint64
+
 	loadout() func(func(string, Item) bool)
+
+	// latest returns the record describing the current state of the slot with the given name.
+	// The [Item] of the returned record is nil if the slot is empty.
+	latest(name string) record
+
+	// records returns the latest record of each [Item] in the inventory.
+	records() func(func(string, record) bool)
+
+	// recall returns the latest records of the slot with the given name, from the oldest to the current one.
+	// Should only be used internally.
+	// Prefer using [History] instead.
+	recall(name string) []record
+
+	// clone returns a new inventory with the same name holding the same items at the same versions with the same history.
+	// The returned inventory shares no state with the original one.
+	clone() Inventory
+
+	// getParents returns the names of the inventories the inventory inherits from, in resolution order.
+	// The names are nil if the inventory declares no parent.
+	getParents() []string
+
+	// inheritFrom sets the names of the inventories the inventory inherits from, in resolution order.
+	// Should only be used internally.
+	// Prefer using [InheritsFrom] instead.
+	inheritFrom(parents []string) Inventory
+
+	// getShadowMode returns the [ShadowMode] of the things hoarded into the inventory, or an unset one if the inventory does not specify it.
+	getShadowMode() ShadowMode
+
+	// shadowWith sets the [ShadowMode] of the things hoarded into the inventory.
+	// Should only be used internally.
+	// Prefer using [WithShadowMode] instead.
+	shadowWith(mode ShadowMode) Inventory
 }
 
 func newInventory(name string) Inventory {
 	return &inventoryImpl{
 		sortedKeys: make([]string, 0),
-		itemMap:    make(map[string]Item),
+		slots:      make(map[string]slotState),
 		name:       name,
 		mu:         sync.RWMutex{},
 	}
 }
 
+// newInventoryWithHistory returns a new inventory that also records the history of each of its slots.
+// Inventories created by the [UseInventory] function and held by a [Hoarder] record their history.
+func newInventoryWithHistory(name string) Inventory {
+	return &inventoryImpl{
+		sortedKeys:     make([]string, 0),
+		slots:          make(map[string]slotState),
+		recordsHistory: true,
+		name:           name,
+		mu:             sync.RWMutex{},
+	}
+}
+
+// inventorySnapshot is a struct that holds the latest record of each slot of an [Inventory], as they were when the snapshot was taken.
+// Unlike a clone, a snapshot leaves the history of the slots out, which makes it cheap to take before merging an [Inventory].
+// This struct is used internally and should not be used directly.
+type inventorySnapshot struct {
+	name    string
+	names   []string
+	records []record
+	parents []string
+}
+
+// slotState is a struct that holds the state of a single slot of an [Inventory].
+// This struct is used internally and should not be used directly.
+type slotState struct {
+
+	// item is the [Item] held by the slot, or nil if the slot is empty.
+	item Item
+
+	// version is the version of the slot, increased each time an [Item] is put into or removed from the slot.
+	version uint64
+
+	// history holds the latest records of the slot, the last one describing the current state of the slot.
+	// It is nil if the inventory does not record its history.
+	history []record
+}
+
 type inventoryImpl struct {
 	sortedKeys []string
-	itemMap    map[string]Item
-	name       string
+
+	// slots holds the state of each slot, including the slots whose item has been removed,
+	// so that a slot's version never goes back.
+	slots map[string]slotState
+
+	// recordsHistory reports whether the inventory records the history of its slots.
+	recordsHistory bool
+	name           string
+
+	// parents holds the names of the inventories the inventory inherits from, in resolution order.
+	// It is nil if the inventory declares no parent.
+	parents []string
+
+	// shadowMode is the [ShadowMode] of the things hoarded into the inventory.
+	// It is only used while the inventory is hoarded, hence it is neither cloned nor merged.
+	shadowMode ShadowMode
 	mu         sync.RWMutex
 }
 
@@ -61,6 +173,21 @@ type inventoryImpl struct {
 //
 //	Hoard(nil, UseInventory("test").Put(RememberAs("test", "test")))
 func (b *inventoryImpl) Put(item Item) Inventory {
+	return b.put(item, newOrigin(0))
+}
+
+// PutIfAbsent adds an [Item] to the inventory if it does not exist yet.
+// The comparison is done by also comparing the alias of the item, hence if the alias is different, it will be considered adifferent item.
+// To get an [Item], refer to the [UseInventory] function.
+//
+// Example:
+//
+//	Hoard(nil, UseInventory("test").PutIfAbsent(RememberAs("test", "test")))
+func (b *inventoryImpl) PutIfAbsent(item Item) Inventory {
+	return b.putIfAbsent(item, newOrigin(0))
+}
+
+func (b *inventoryImpl) put(item Item, o origin) Inventory {
 	if item == nil {
 		return b
 	}
@@ -68,23 +195,12 @@ func (b *inventoryImpl) Put(item Item) Inventory {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	b.itemMap[item.getName()] = item
-	b.sortedKeys = slices.DeleteFunc(b.sortedKeys, func(e string) bool {
-		return e == item.getName()
-	})
-	b.sortedKeys = append(b.sortedKeys, item.getName())
+	b.setLocked(item.getName(), item, o)
 
 	return b
 }
 
-// PutIfAbsent adds an [Item] to the inventory if it does not exist yet.
-// The comparison is done by also comparing the alias of the item, hence if the alias is different, it will be considered adifferent item.
-// To get an [Item], refer to the [UseInventory] function.
-//
-// Example:
-//
-//	Hoard(nil, UseInventory("test").PutIfAbsent(RememberAs("test", "test")))
-func (b *inventoryImpl) PutIfAbsent(item Item) Inventory {
+func (b *inventoryImpl) putIfAbsent(item Item, o origin) Inventory {
 	if item == nil {
 		return b
 	}
@@ -92,14 +208,25 @@ func (b *inventoryImpl) PutIfAbsent(item Item) Inventory {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	if _, ok := b.itemMap[item.getName()]; !ok {
-		b.itemMap[item.getName()] = item
-		b.sortedKeys = append(b.sortedKeys, item.getName())
+	if b.slots[item.getName()].item == nil {
+		b.setLocked(item.getName(), item, o)
 	}
 
 	return b
 }
 
+// setLocked puts the given [Item] into the slot with the given name and records the change.
+// Must be called while holding the lock of the inventory.
+func (b *inventoryImpl) setLocked(name string, item Item, o origin) {
+	b.changeLocked(name, item, o)
+
+	// re-insert the key to ensure the order is consistent
+	b.sortedKeys = slices.DeleteFunc(b.sortedKeys, func(e string) bool {
+		return e == name
+	})
+	b.sortedKeys = append(b.sortedKeys, name)
+}
+
 func (b *inventoryImpl) getName() string {
 	return b.name
 }
@@ -108,13 +235,7 @@ func (b *inventoryImpl) equip(name string) Item {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
-	v, ok := b.itemMap[name]
-
-	if !ok {
-		return nil
-	}
-
-	return v
+	return b.slots[name].item
 }
 
 func (b *inventoryImpl) merge(invent Inventory) Inventory {
@@ -122,20 +243,79 @@ func (b *inventoryImpl) merge(invent Inventory) Inventory {
 		return b
 	}
 
+	// snapshot the given inventory before acquiring our own lock,
+	// so that two inventories merging into each other never hold both locks at once
+	return b.mergeSnapshot(invent.snapshot())
+}
+
+func (b *inventoryImpl) mergeSnapshot(snapshot inventorySnapshot) Inventory {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	for k, v := range invent.loadout() {
-		b.itemMap[k] = v
+	for i, k := range snapshot.names {
+		// a slot already holding the same thing from the same hoarding, e.g. merged again, is left unchanged
+		if b.holdsLocked(k, snapshot.records[i]) {
+			continue
+		}
+
+		b.setLocked(k, snapshot.records[i].item, snapshot.records[i].origin)
+	}
+
+	// declaring the parents again replaces them
+	if snapshot.parents != nil {
+		b.parents = snapshot.parents
+	}
+
+	return b
+}
+
+// holdsLocked reports whether the slot with the given name holds the [Item] of the given record, from the same hoarding.
+// Must be called while holding the lock of the inventory.
+func (b *inventoryImpl) holdsLocked(name string, r record) bool {
+	s := b.slots[name]
+	if s.item == nil || s.item != r.item {
+		return false
+	}
+
+	if len(s.history) > 0 {
+		return s.history[len(s.history)-1].hoarding == r.hoarding
+	}
+
+	return true
+}
+
+// changeLocked puts the given [Item] into the slot with the given name, or empties the slot if the [Item] is nil,
+// increases the version of the slot and records the change if the inventory records its history.
+// Must be called while holding the lock of the inventory.
+func (b *inventoryImpl) changeLocked(name string, item Item, o origin) {
+	s := b.slots[name]
+	s.item = item
+	s.version++
 
-		// re-insert the key to ensure the order is consistent
-		b.sortedKeys = slices.DeleteFunc(b.sortedKeys, func(e string) bool {
-			return e == k
+	if b.recordsHistory {
+		s.history = appendRecord(s.history, record{
+			item:    item,
+			version: s.version,
+			origin:  o,
 		})
+	}
+
+	b.slots[name] = s
+}
+
+func (b *inventoryImpl) remove(name string, o origin) Inventory {
+	b.mu.Lock()
+	defer b.mu.Unlock()
 
-		b.sortedKeys = append(b.sortedKeys, k)
+	if b.slots[name].item == nil {
+		return b
 	}
 
+	b.changeLocked(name, nil, o)
+	b.sortedKeys = slices.DeleteFunc(b.sortedKeys, func(e string) bool {
+		return e == name
+	})
+
 	return b
 }
 
@@ -145,9 +325,127 @@ func (b *inventoryImpl) loadout() func(func(string, Item) bool) {
 		defer b.mu.RUnlock()
 
 		for _, k := range b.sortedKeys {
-			if !yield(k, b.itemMap[k]) {
+			if !yield(k, b.slots[k].item) {
 				break
 			}
 		}
 	}
 }
+
+func (b *inventoryImpl) version(name string) uint64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.slots[name].version
+}
+
+func (b *inventoryImpl) records() func(func(string, record) bool) {
+	return func(yield func(string, record) bool) {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+
+		for _, k := range b.sortedKeys {
+			if !yield(k, b.latestLocked(k)) {
+				break
+			}
+		}
+	}
+}
+
+func (b *inventoryImpl) snapshot() inventorySnapshot {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	snapshot := inventorySnapshot{
+		name:    b.name,
+		names:   slices.Clone(b.sortedKeys),
+		records: make([]record, 0, len(b.sortedKeys)),
+		parents: slices.Clone(b.parents),
+	}
+
+	for _, k := range b.sortedKeys {
+		snapshot.records = append(snapshot.records, b.latestLocked(k))
+	}
+
+	return snapshot
+}
+
+func (b *inventoryImpl) latest(name string) record {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.latestLocked(name)
+}
+
+// latestLocked returns the record describing the current state of the slot with the given name, as the latest method does.
+// Must be called while holding the lock of the inventory.
+func (b *inventoryImpl) latestLocked(name string) record {
+	s := b.slots[name]
+	if len(s.history) > 0 {
+		return s.history[len(s.history)-1]
+	}
+
+	return record{
+		item:    s.item,
+		version: s.version,
+	}
+}
+
+func (b *inventoryImpl) recall(name string) []record {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return slices.Clone(b.slots[name].history)
+}
+
+func (b *inventoryImpl) clone() Inventory {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	slots := make(map[string]slotState, len(b.slots))
+	for k, s := range b.slots {
+		s.history = slices.Clone(s.history)
+		slots[k] = s
+	}
+
+	return &inventoryImpl{
+		sortedKeys:     slices.Clone(b.sortedKeys),
+		slots:          slots,
+		recordsHistory: b.recordsHistory,
+		name:           b.name,
+		parents:        slices.Clone(b.parents),
+		mu:             sync.RWMutex{},
+	}
+}
+
+func (b *inventoryImpl) getParents() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return slices.Clone(b.parents)
+}
+
+func (b *inventoryImpl) inheritFrom(parents []string) Inventory {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.parents = slices.Clone(parents)
+
+	return b
+}
+
+func (b *inventoryImpl) getShadowMode() ShadowMode {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.shadowMode
+}
+
+func (b *inventoryImpl) shadowWith(mode ShadowMode) Inventory {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.shadowMode = mode
+
+	return b
+}
@@ -1,6 +1,7 @@
 package hoard
 
 import (
+	"sync"
 	"time"
 
 	"github.com/stretchr/testify/require"
@@ -365,3 +366,69 @@ func (s *suiteTest) TestLoadout() {
 		})
 	}
 }
+
+func (s *suiteTest) TestMerge_concurrent() {
+	tests := []struct {
+		name       string
+		goroutines int
+	}{
+		{
+			name:       "merging inventories into each other and themselves concurrently should not deadlock nor race",
+			goroutines: 50,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			a := newInventory("a").Put(newItem("test thing", "test"))
+			b := newInventory("b").Put(newItem("test thing2", "test2"))
+
+			done := make(chan struct{})
+
+			go func() {
+				defer close(done)
+
+				wg := sync.WaitGroup{}
+				for i := 0; i < tt.goroutines; i++ {
+					wg.Add(3)
+					go func() {
+						defer wg.Done()
+						a.merge(b)
+					}()
+					go func() {
+						defer wg.Done()
+						b.merge(a)
+					}()
+					go func() {
+						defer wg.Done()
+						a.merge(a)
+					}()
+				}
+				wg.Wait()
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(10 * time.Second):
+				s.FailNow("merging inventories into each other deadlocked")
+			}
+
+			require.Equal(s.T(), "test thing2", a.equip("test2").use())
+			require.Equal(s.T(), "test thing", b.equip("test").use())
+		})
+	}
+}
+
+func (s *suiteTest) TestClone() {
+	s.invent.Put(newItem("test thing", "test"))
+
+	got := s.invent.clone()
+
+	require.NotSame(s.T(), s.invent, got)
+	require.Equal(s.T(), s.invent.getName(), got.getName())
+	require.Same(s.T(), s.invent.equip("test"), got.equip("test"))
+
+	s.invent.Put(newItem("test thing2", "test2"))
+
+	require.Nil(s.T(), got.equip("test2"))
+}
@@ -6,6 +6,23 @@ package hoard
 type Item interface {
 	getName() string
 	use() interface{}
+
+	// getMeta returns the metadata of the item, which is kept when the item is copied under another name.
+	getMeta() itemMeta
+}
+
+// itemMeta is a struct that holds the metadata of an [Item].
+// This struct is used internally and should not be used directly.
+type itemMeta struct {
+
+	// revealed is a boolean that reports whether the thing may be shown by the [DebugHandler].
+	revealed bool
+
+	// exact is a boolean that reports whether the item is hoarded under the exact name of a [Key], which wins over the names derived from the other items.
+	exact bool
+
+	// labels holds the key-value labels the thing may be selected with.
+	labels map[string]string
 }
 
 func newItem(thing interface{}, name string) Item {
@@ -15,9 +32,19 @@ func newItem(thing interface{}, name string) Item {
 	}
 }
 
+// copyItem returns a new item holding the same thing with the same metadata as the given item, under the given name.
+func copyItem(item Item, name string) Item {
+	return &itemImpl{
+		thing: item.use(),
+		name:  name,
+		meta:  item.getMeta(),
+	}
+}
+
 type itemImpl struct {
 	thing interface{}
 	name  string
+	meta  itemMeta
 }
 
 func (i *itemImpl) getName() string {
@@ -27,3 +54,7 @@ func (i *itemImpl) getName() string {
 func (i *itemImpl) use() interface{} {
 	return i.thing
 }
+
+func (i *itemImpl) getMeta() itemMeta {
+	return i.meta
+}
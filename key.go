@@ -0,0 +1,95 @@
+package hoard
+
+import (
+	"reflect"
+)
+
+// Key is a precomputed lookup key of a thing of type T, with a custom [Item] name in a custom [Inventory].
+// Unlike the [EquipOptions], the name the thing is hoarded under is computed once, when the [Key] is created,
+// and the thing is only looked up under that exact name, without falling back to the custom [Item] name alone or to the implementations of an interface.
+// To create a new key, use the [NewKey] function instead.
+//
+// Keys are typically declared by the code generated by the hoardgen command, refer to the cmd/hoardgen package for more details.
+type Key[T any] struct {
+
+	// typeOfThing is the type of the thing.
+	typeOfThing reflect.Type
+
+	// inventoryName is the name of the [Inventory] the thing is hoarded into and equipped from.
+	inventoryName string
+
+	// customInventoryName is the custom [Inventory] name as given to the [NewKey] function.
+	customInventoryName string
+
+	// itemName is the custom [Item] name of the thing.
+	itemName string
+
+	// thingName is the exact name the thing is hoarded under.
+	thingName string
+}
+
+// NewKey is a function that returns a new [Key] of the thing of type T with the given custom [Item] name in the given custom [Inventory].
+// Passing an empty string as the [Inventory] name uses the default [Inventory], and passing an empty string as the [Item] name uses the default name.
+//
+// Example usage:
+//
+//	var primaryDB = NewKey[*sql.DB]("databases", "primary")
+//
+//	Hoard(nil, primaryDB.Wrap(db))
+//	db := primaryDB.Equip()
+func NewKey[T any](inventory, name string) Key[T] {
+	typeOfThing := reflect.TypeFor[T]()
+
+	return Key[T]{
+		typeOfThing:         typeOfThing,
+		inventoryName:       getCustomInventoryName(inventory),
+		customInventoryName: inventory,
+		itemName:            name,
+		thingName:           getCustomThingName(name, typeOfThing),
+	}
+}
+
+// Wrap is a method that returns the given thing ready to be hoarded under the [Key], to be passed to the [Hoard] function or the [Tx.Put] method.
+// The thing is hoarded under the exact name of the [Key], even if T is an interface,
+// whatever the order it is hoarded in along with the things of other keys of the same type.
+// The method returns an [Item], or an [Inventory] holding the [Item] if the [Key] has a custom [Inventory] name.
+func (k Key[T]) Wrap(thing T) interface{} {
+	item := &itemImpl{
+		thing: thing,
+		name:  k.thingName,
+		meta:  itemMeta{exact: true},
+	}
+
+	if k.customInventoryName == "" {
+		return item
+	}
+
+	return UseInventory(k.customInventoryName).Put(item)
+}
+
+// Equip is a method that returns the thing hoarded under the [Key].
+// The method refers to the global [Hoarder] to get the thing unless a custom [Hoarder] is specified.
+// The method returns the thing if found. Otherwise, it panics the same way as the [EquipWithOption] function does.
+//
+// The [Key.Equip] method is thread-safe.
+func (k Key[T]) Equip(customHoarder ...Hoarder) T {
+	var thing interface{}
+
+	if v, _ := pickHoarder(customHoarder...).equipExact(k.typeOfThing, k.inventoryName, k.itemName, k.thingName); v != nil {
+		thing = v.use()
+	}
+
+	return thing.(T)
+}
+
+// String is a method that returns a human-readable description of the thing hoarded under the [Key].
+// Example output:
+//
+//	*sql.DB named "primary" in inventory databases
+func (k Key[T]) String() string {
+	if k.typeOfThing == nil {
+		return "<nil> in inventory " + getOriginalInventoryName(k.inventoryName)
+	}
+
+	return describeItem(k.typeOfThing.String(), k.itemName, getOriginalInventoryName(k.inventoryName))
+}
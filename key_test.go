@@ -0,0 +1,62 @@
+package hoard
+
+import (
+	"github.com/stretchr/testify/require"
+)
+
+func (s *suiteTest) TestKey() {
+	h := factory()
+
+	primary := NewKey[*TestFooImpl]("test", "primary")
+	answer := NewKey[int]("", "answer")
+	fooer := NewKey[TestFooer]("", "")
+
+	Hoard(
+		HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h),
+		primary.Wrap(&TestFooImpl{Name: "primary"}),
+		answer.Wrap(42),
+		fooer.Wrap(TestFooImpl{Name: "fooer"}),
+	)
+
+	require.Equal(s.T(), "primary", primary.Equip(h).Name)
+	require.Equal(s.T(), 42, answer.Equip(h))
+	require.Equal(s.T(), "fooer", fooer.Equip(h).getName())
+
+	// the things are hoarded the same way as with the RememberAs function
+	require.Equal(s.T(), "primary", EquipWithOption[*TestFooImpl](EquipOptions{}.WithCustomInventoryName("test").WithCustomItemName("primary"), h).Name)
+	require.Equal(s.T(), "primary", EquipWithOption[*TestFooImpl](EquipOptions{}.WithCustomItemName("primary"), h).Name)
+	require.Equal(s.T(), 42, EquipWithOption[int](EquipOptions{}.WithCustomItemName("answer"), h))
+
+	require.Equal(s.T(), `*hoard.TestFooImpl named "primary" in inventory test`, primary.String())
+	require.Equal(s.T(), "hoard.TestFooer in inventory default", fooer.String())
+	require.Equal(s.T(), "<nil> in inventory ", Key[int]{}.String())
+}
+
+func (s *suiteTest) TestKey_exact() {
+	h := factory()
+
+	Hoard(
+		HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h),
+		RememberAs(42, "answer"),
+		&TestFooImpl{},
+	)
+
+	// neither the alias nor the implementations of an interface are looked up
+	require.Panics(s.T(), func() {
+		_ = NewKey[int64]("", "answer").Equip(h)
+	})
+	require.Panics(s.T(), func() {
+		_ = NewKey[TestFooer]("", "").Equip(h)
+	})
+	require.Panics(s.T(), func() {
+		_ = NewKey[int]("missing", "answer").Equip(h)
+	})
+
+	require.Equal(s.T(), 42, NewKey[int]("", "answer").Equip(h))
+	require.Equal(s.T(), 42, NewKey[int]("", "").Equip(h))
+
+	got := h.Stats().Equips
+
+	require.Len(s.T(), got, 4)
+	require.Equal(s.T(), EquipStats{Inventory: "default", Type: "int", Equips: 2}, got[1])
+}
@@ -0,0 +1,284 @@
+package hoard
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrNoMatch is returned, wrapped, when no thing matches the selector given with the [EquipOptions.WithSelector] method.
+	ErrNoMatch = errors.New("hoard: no match")
+
+	// ErrAmbiguousMatch is returned, wrapped, when several things match the selector given with the [EquipOptions.WithSelector] method
+	// while exactly one is expected.
+	ErrAmbiguousMatch = errors.New("hoard: ambiguous match")
+
+	// ErrInvalidSelector is returned, wrapped, when the selector given with the [EquipOptions.WithSelector] method cannot be parsed.
+	ErrInvalidSelector = errors.New("hoard: invalid selector")
+)
+
+// Label is a function that wraps the given thing into an [Item] carrying the given key-value labels, to be equipped with a selector,
+// refer to the [EquipOptions.WithSelector] method.
+// The given thing may be an [Item] created by the [RememberAs] or [Reveal] functions, which keeps its custom name, and its labels if any,
+// the given labels replacing the ones of the same key.
+// A thing without a custom name is named after its labels, e.g. "{region=eu,tier=gold}", so that the things of the same type told apart by their labels
+// do not replace each other.
+// Example usage:
+//
+//	Hoard(nil, Label(RememberAs(euDB, "eu"), map[string]string{"region": "eu", "tier": "gold"}))
+//	Hoard(nil, UseInventory("payments").Put(Label(usDB, map[string]string{"region": "us"})))
+func Label(thing interface{}, labels map[string]string) Item {
+	if thing == nil {
+		return nil
+	}
+
+	item, ok := thing.(Item)
+	if !ok {
+		item = newItem(thing, getThingName(getTypeOfThing(thing)))
+	}
+
+	meta := item.getMeta()
+	meta.labels = maps.Clone(meta.labels)
+
+	if meta.labels == nil {
+		meta.labels = make(map[string]string, len(labels))
+	}
+
+	maps.Copy(meta.labels, labels)
+
+	// a thing named after its labels is renamed after its new ones
+	name := item.getName()
+	if alias := getAliasThingName(name); (alias == "" || alias == labelsName(item.getMeta().labels)) && !meta.exact && len(meta.labels) > 0 {
+		name = getCustomThingName(labelsName(meta.labels), getTypeOfThing(item.use()))
+	}
+
+	return &itemImpl{
+		thing: item.use(),
+		name:  name,
+		meta:  meta,
+	}
+}
+
+// labelsName is a function that returns the canonical name of the given labels, sorted by key, e.g. "{region=eu,tier=gold}".
+func labelsName(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		pairs = append(pairs, k+"="+labels[k])
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// WithSelector is a method that sets the [selector] field in the [equipConfig] struct to the given value.
+// The method returns a new [EquipOptions] with the updated configuration.
+// Typical usage of this method is to equip a thing by its labels, refer to the [Label] function, rather than by its custom [Item] name.
+//
+// The selector is a comma-separated list of requirements, all of which the labels of the thing must meet:
+// "key=value" or "key==value" for a label of the given value, "key!=value" for a label of another value or no such label,
+// "key" for a label of any value and "!key" for no such label.
+//
+// The thing is resolved from the specified [Inventory], then from the inventories it inherits from and the fallback inventories,
+// among the things whose type is the requested one, or implements it if it is an interface, the same way as the [EquipWithOption] function does.
+// Exactly one thing of the first [Inventory] holding a match must match, otherwise the [EquipWithOption] function panics with an error wrapping
+// [ErrAmbiguousMatch] or, if no [Inventory] holds a match, [ErrNoMatch]. The [EquipOr] function returns its fallback thing instead,
+// while the [EquipAll] function returns every match.
+//
+// The selector is honoured by the [EquipWithOption], [EquipOr], [EquipAll] and [Need] functions, and ignored by the other ones.
+//
+// Example usage:
+//
+//	EquipWithOption[*sql.DB](EquipOptions{}.WithSelector("region=eu,tier!=free"))
+//	EquipAll[*sql.DB](EquipOptions{}.WithInventoryPattern("**").WithSelector("region=eu"))
+func (h EquipOptions) WithSelector(selector string) EquipOptions {
+	return append(h, newFuncEquipOptions(func(opt *equipConfig) *equipConfig {
+		opt.selector = selector
+		return opt
+	}))
+}
+
+// selectorOperator is a type that describes how a requirement of a selector compares a label.
+type selectorOperator int
+
+const (
+	selectorEquals selectorOperator = iota
+	selectorNotEquals
+	selectorExists
+	selectorNotExists
+)
+
+// selectorRequirement is a struct that describes a requirement of a selector on a single label.
+type selectorRequirement struct {
+	key      string
+	operator selectorOperator
+	value    string
+}
+
+// selector is a type that holds the requirements of a selector, all of which the labels of a thing must meet.
+type selector []selectorRequirement
+
+// parseSelector is a function that parses the given selector, refer to the [EquipOptions.WithSelector] method for its syntax.
+// An empty selector has no requirement, hence matches every thing.
+func parseSelector(s string) (selector, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	sel := make(selector, 0)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+
+		var r selectorRequirement
+		switch {
+		case strings.Contains(part, "!="):
+			r.key, r.value, _ = strings.Cut(part, "!=")
+			r.operator = selectorNotEquals
+		case strings.Contains(part, "=="):
+			r.key, r.value, _ = strings.Cut(part, "==")
+			r.operator = selectorEquals
+		case strings.Contains(part, "="):
+			r.key, r.value, _ = strings.Cut(part, "=")
+			r.operator = selectorEquals
+		case strings.HasPrefix(part, "!"):
+			r.key = strings.TrimPrefix(part, "!")
+			r.operator = selectorNotExists
+		default:
+			r.key = part
+			r.operator = selectorExists
+		}
+
+		r.key, r.value = strings.TrimSpace(r.key), strings.TrimSpace(r.value)
+
+		if r.key == "" || strings.ContainsAny(r.key, "!=") || strings.ContainsAny(r.value, "!=") {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSelector, s)
+		}
+
+		sel = append(sel, r)
+	}
+
+	return sel, nil
+}
+
+// matches is a method that reports whether the given labels meet every requirement of the selector.
+func (sel selector) matches(labels map[string]string) bool {
+	for _, r := range sel {
+		value, ok := labels[r.key]
+
+		switch r.operator {
+		case selectorEquals:
+			if !ok || value != r.value {
+				return false
+			}
+		case selectorNotEquals:
+			if ok && value == r.value {
+				return false
+			}
+		case selectorExists:
+			if !ok {
+				return false
+			}
+		case selectorNotExists:
+			if ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// selectAcross is a function that returns the slot of the only thing matching the given selector in the first of the given inventories,
+// or of the inventories they inherit from, holding a match.
+// The function returns an error wrapping [ErrNoMatch], [ErrAmbiguousMatch] or [ErrInvalidSelector] if there is no such slot.
+func selectAcross(h Hoarder, typeOfThing reflect.Type, inventoryNames []string, itemName, selector string) (enumeratedSlot, error) {
+	sel, err := parseSelector(selector)
+	if err != nil {
+		return enumeratedSlot{}, err
+	}
+
+	inventories := make(map[string]Inventory)
+	for k, v := range h.loadout() {
+		inventories[k] = v
+	}
+
+	// the inventories are walked along with the inventories they inherit from, the same way as the hoarder does
+	chain := make([]string, 0, len(inventoryNames))
+
+	var walk func(name string)
+	walk = func(name string) {
+		if slices.Contains(chain, name) {
+			return
+		}
+
+		chain = append(chain, name)
+
+		if inventoryImpl, ok := inventories[name]; ok {
+			for _, parent := range inventoryImpl.getParents() {
+				walk(parent)
+			}
+		}
+	}
+
+	for _, name := range inventoryNames {
+		walk(name)
+	}
+
+	for _, name := range chain {
+		inventoryImpl, ok := inventories[name]
+		if !ok {
+			continue
+		}
+
+		matches := make([]enumeratedSlot, 0)
+		for _, s := range inventorySlots(name, inventoryImpl, typeOfThing, true) {
+			if itemName != "" && s.itemName != itemName {
+				continue
+			}
+
+			if sel.matches(s.labels) {
+				matches = append(matches, s)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return matches[0], nil
+		}
+
+		slices.SortFunc(matches, compareEnumeratedSlots)
+
+		found := make([]string, 0, len(matches))
+		for _, s := range matches {
+			found = append(found, s.describe())
+		}
+
+		return enumeratedSlot{}, fmt.Errorf("%w: %s with selector %q, found %s",
+			ErrAmbiguousMatch, describeItem(typeOfThing.String(), itemName, getOriginalInventoryName(inventoryNames[0])), selector, strings.Join(found, ", "))
+	}
+
+	return enumeratedSlot{}, fmt.Errorf("%w: %s with selector %q",
+		ErrNoMatch, describeItem(typeOfThing.String(), itemName, getOriginalInventoryName(inventoryNames[0])), selector)
+}
+
+// equipSelected is a function that equips the only thing matching the given selector, the same way as the selectAcross function resolves it.
+// The lookup is reported for the [Inventory] the thing is found in.
+func equipSelected(h Hoarder, typeOfThing reflect.Type, inventoryNames []string, itemName, selector string) (Item, error) {
+	s, err := selectAcross(h, typeOfThing, inventoryNames, itemName, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	v, _ := h.equipExact(typeOfThing, s.inventoryName, s.itemName, s.name)
+
+	// the slot may have changed since the thing was selected
+	if v == nil {
+		return nil, fmt.Errorf("%w: %s with selector %q", ErrNoMatch, s.describe(), selector)
+	}
+
+	return v, nil
+}
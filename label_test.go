@@ -0,0 +1,231 @@
+package hoard
+
+import (
+	"github.com/stretchr/testify/require"
+)
+
+type testLabelDB struct {
+	Name string
+}
+
+func (db *testLabelDB) name() string {
+	return db.Name
+}
+
+type testLabelNamer interface {
+	name() string
+}
+
+func (s *suiteTest) TestWithSelector() {
+	opt := HoardOptions{}.ShouldReplaceGlobal(false)
+
+	h := Hoard(opt,
+		Label(RememberAs(&testLabelDB{Name: "eu-gold"}, "eu-gold"), map[string]string{"region": "eu", "tier": "gold"}),
+		Label(RememberAs(&testLabelDB{Name: "eu-free"}, "eu-free"), map[string]string{"region": "eu", "tier": "free"}),
+		Label(RememberAs(&testLabelDB{Name: "us-gold"}, "us-gold"), map[string]string{"region": "us", "tier": "gold"}),
+		Label(RememberAs(&testLabelDB{Name: "unlabelled"}, "unlabelled"), nil),
+		UseInventory("payments", WithShadowMode(ShadowIsolated)).
+			Put(Label(&testLabelDB{Name: "payments"}, map[string]string{"region": "eu"})),
+	)
+
+	tests := []struct {
+		name    string
+		opt     EquipOptions
+		want    string
+		wantErr error
+	}{
+		{
+			name: "should equip the only thing matching every requirement",
+			opt:  EquipOptions{}.WithSelector("region=eu,tier!=free"),
+			want: "eu-gold",
+		},
+		{
+			name: "should equip with a double equal sign",
+			opt:  EquipOptions{}.WithSelector("region==us"),
+			want: "us-gold",
+		},
+		{
+			name: "should equip the thing without the label",
+			opt:  EquipOptions{}.WithSelector("!region"),
+			want: "unlabelled",
+		},
+		{
+			name: "should equip the thing matching the custom item name",
+			opt:  EquipOptions{}.WithCustomItemName("eu-free").WithSelector("region"),
+			want: "eu-free",
+		},
+		{
+			name: "should equip from the specified inventory first",
+			opt:  EquipOptions{}.WithCustomInventoryName("payments").WithFallbackInventories("").WithSelector("region=eu"),
+			want: "payments",
+		},
+		{
+			name: "should equip from the fallback inventory",
+			opt:  EquipOptions{}.WithCustomInventoryName("payments").WithFallbackInventories("").WithSelector("region=us"),
+			want: "us-gold",
+		},
+		{
+			name:    "should fail if several things match",
+			opt:     EquipOptions{}.WithSelector("tier=gold"),
+			wantErr: ErrAmbiguousMatch,
+		},
+		{
+			name:    "should fail if no thing matches",
+			opt:     EquipOptions{}.WithSelector("region=apac"),
+			wantErr: ErrNoMatch,
+		},
+		{
+			name:    "should fail if the selector is invalid",
+			opt:     EquipOptions{}.WithSelector("region=eu,,tier"),
+			wantErr: ErrInvalidSelector,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			if tt.wantErr != nil {
+				require.ErrorIs(s.T(), recoverError(func() { EquipWithOption[*testLabelDB](tt.opt, h) }), tt.wantErr)
+				require.Equal(s.T(), "fallback", EquipOr(tt.opt, &testLabelDB{Name: "fallback"}, h).Name)
+
+				return
+			}
+
+			require.Equal(s.T(), tt.want, EquipWithOption[*testLabelDB](tt.opt, h).Name)
+			require.Equal(s.T(), tt.want, EquipOr(tt.opt, &testLabelDB{Name: "fallback"}, h).Name)
+		})
+	}
+
+	require.Equal(s.T(), "eu-gold", EquipWithOption[testLabelNamer](EquipOptions{}.WithSelector("region=eu,tier=gold"), h).name())
+
+	err := recoverError(func() { EquipWithOption[*testLabelDB](EquipOptions{}.WithSelector("tier=gold"), h) })
+	require.EqualError(s.T(), err, `hoard: ambiguous match: *hoard.testLabelDB in inventory default with selector "tier=gold", `+
+		`found *hoard.testLabelDB named "eu-gold" in inventory default, *hoard.testLabelDB named "us-gold" in inventory default`)
+
+	h.Require(Need[*testLabelDB](EquipOptions{}.WithSelector("region=us")))
+	require.NoError(s.T(), h.Verify())
+
+	h.Require(
+		Need[*testLabelDB](EquipOptions{}.WithSelector("tier=gold")),
+		Need[*testLabelDB](EquipOptions{}.WithSelector("region=apac")),
+		Need[*testLabelDB](EquipOptions{}.WithSelector("region=apac")).Optional(),
+	)
+
+	err = h.Verify()
+	require.ErrorIs(s.T(), err, ErrRequirementNotMet)
+	require.ErrorIs(s.T(), err, ErrAmbiguousMatch)
+	require.ErrorContains(s.T(), err, `hoard: requirement not met: *hoard.testLabelDB in inventory default with selector "region=apac" is not hoarded`)
+}
+
+func (s *suiteTest) TestEquipAll_withSelector() {
+	opt := HoardOptions{}.ShouldReplaceGlobal(false)
+
+	h := Hoard(opt,
+		UseInventory("payments/eu").Put(Label(RememberAs(&testLabelDB{Name: "eu-gold"}, "gold"), map[string]string{"tier": "gold"})),
+		UseInventory("payments/us").
+			Put(Label(RememberAs(&testLabelDB{Name: "us-gold"}, "gold"), map[string]string{"tier": "gold"})).
+			Put(Label(RememberAs(&testLabelDB{Name: "us-free"}, "free"), map[string]string{"tier": "free"})),
+	)
+
+	names := func(dbs []*testLabelDB) []string {
+		names := make([]string, 0, len(dbs))
+		for _, db := range dbs {
+			names = append(names, db.Name)
+		}
+
+		return names
+	}
+
+	require.Equal(s.T(), []string{"eu-gold", "us-gold"}, names(EquipAll[*testLabelDB](EquipOptions{}.WithInventoryPattern("payments/*").WithSelector("tier=gold"), h)))
+	require.Equal(s.T(), []string{"us-free"}, names(EquipAll[*testLabelDB](EquipOptions{}.WithInventoryPattern("payments/*").WithSelector("tier!=gold"), h)))
+	require.Empty(s.T(), EquipAll[*testLabelDB](EquipOptions{}.WithInventoryPattern("payments/*").WithSelector("=gold"), h))
+}
+
+func (s *suiteTest) TestLabel() {
+	item := Label(Reveal(RememberAs(1, "one")), map[string]string{"region": "eu", "tier": "free"})
+	item = Label(item, map[string]string{"tier": "gold"})
+
+	require.Equal(s.T(), "int\none", item.getName())
+	require.True(s.T(), item.getMeta().revealed)
+	require.Equal(s.T(), map[string]string{"region": "eu", "tier": "gold"}, item.getMeta().labels)
+
+	require.Nil(s.T(), Label(nil, nil))
+
+	item = Label(2, map[string]string{"tier": "free", "region": "eu"})
+	require.Equal(s.T(), "int\n{region=eu,tier=free}", item.getName())
+
+	item = Label(item, map[string]string{"tier": "gold"})
+	require.Equal(s.T(), "int\n{region=eu,tier=gold}", item.getName())
+
+	require.Equal(s.T(), "int", Label(3, nil).getName())
+}
+
+func (s *suiteTest) TestLabel_unnamedThingsOfTheSameType() {
+	opt := HoardOptions{}.ShouldReplaceGlobal(false)
+
+	h := Hoard(opt,
+		Label(&testLabelDB{Name: "eu"}, map[string]string{"region": "eu"}),
+		Label(&testLabelDB{Name: "us"}, map[string]string{"region": "us"}),
+		UseInventory("payments").
+			Put(Label(&testLabelDB{Name: "payments-eu"}, map[string]string{"region": "eu"})).
+			Put(Label(&testLabelDB{Name: "payments-us"}, map[string]string{"region": "us"})),
+	)
+
+	require.Equal(s.T(), "eu", EquipWithOption[*testLabelDB](EquipOptions{}.WithSelector("region=eu"), h).Name)
+	require.Equal(s.T(), "us", EquipWithOption[*testLabelDB](EquipOptions{}.WithSelector("region=us"), h).Name)
+	require.Equal(s.T(), "eu", EquipDefault[*testLabelDB](h).Name)
+
+	payments := EquipOptions{}.WithCustomInventoryName("payments")
+	require.Equal(s.T(), "payments-eu", EquipWithOption[*testLabelDB](payments.WithSelector("region=eu"), h).Name)
+	require.Equal(s.T(), "payments-us", EquipWithOption[*testLabelDB](payments.WithSelector("region=us"), h).Name)
+
+	require.Len(s.T(), EquipAll[*testLabelDB](EquipOptions{}.WithInventoryPattern("**"), h), 4)
+}
+
+func (s *suiteTest) TestParseSelector() {
+	tests := []struct {
+		selector string
+		labels   map[string]string
+		want     bool
+		wantErr  bool
+	}{
+		{selector: "", labels: nil, want: true},
+		{selector: "region=eu", labels: map[string]string{"region": "eu"}, want: true},
+		{selector: "region = eu", labels: map[string]string{"region": "eu"}, want: true},
+		{selector: "region=eu", labels: map[string]string{"region": "us"}, want: false},
+		{selector: "region=eu", labels: nil, want: false},
+		{selector: "region!=eu", labels: nil, want: true},
+		{selector: "region!=eu", labels: map[string]string{"region": "eu"}, want: false},
+		{selector: "region", labels: map[string]string{"region": ""}, want: true},
+		{selector: "!region", labels: map[string]string{"region": ""}, want: false},
+		{selector: "region=eu,tier", labels: map[string]string{"region": "eu"}, want: false},
+		{selector: "region=", labels: map[string]string{"region": ""}, want: true},
+		{selector: "=eu", wantErr: true},
+		{selector: "region=eu,", wantErr: true},
+		{selector: "region=e=u", wantErr: true},
+		{selector: "!region=eu", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.selector, func() {
+			sel, err := parseSelector(tt.selector)
+			if tt.wantErr {
+				require.ErrorIs(s.T(), err, ErrInvalidSelector)
+				return
+			}
+
+			require.NoError(s.T(), err)
+			require.Equal(s.T(), tt.want, sel.matches(tt.labels))
+		})
+	}
+}
+
+// recoverError is a function that returns the error the given function panics with, or nil if it does not panic with an error.
+func recoverError(f func()) (err error) {
+	defer func() {
+		err, _ = recover().(error)
+	}()
+
+	f()
+
+	return nil
+}
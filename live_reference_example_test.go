@@ -0,0 +1,28 @@
+package hoard_test
+
+import (
+	"fmt"
+
+	"github.com/oopchi/hoard"
+)
+
+type RConfig struct {
+	Endpoint string
+}
+
+func ExampleRef() {
+	customHoarder := hoard.Hoard(hoard.HoardOptions{}.ShouldReplaceGlobal(false), &RConfig{Endpoint: "v1.example.com"})
+
+	// Reference the config once, e.g. when building a handler
+	ref := hoard.Ref[*RConfig](nil, customHoarder)
+	defer ref.Release()
+
+	fmt.Println(ref.Get().Endpoint)
+
+	// Replacing the config later on is picked up by the reference without equipping it again
+	hoard.Hoard(hoard.HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(customHoarder), &RConfig{Endpoint: "v2.example.com"})
+
+	fmt.Println(ref.Get().Endpoint)
+	// Output: v1.example.com
+	// v2.example.com
+}
@@ -0,0 +1,113 @@
+package hoard
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Reference is a live reference to a hoarded thing of type T.
+// Unlike the thing returned by [EquipWithOption], a [Reference] follows the [Inventory] it was created for:
+// whenever the thing is replaced, merged over or removed, the [Reference] is updated accordingly.
+// To create a new reference, use the [Ref] function instead.
+// All methods in this struct are thread-safe.
+type Reference[T any] struct {
+
+	// thing is a pointer to the currently referenced thing, or nil if the thing is not hoarded.
+	thing atomic.Pointer[T]
+
+	// hoarder is the [Hoarder] the reference resolves the thing from.
+	hoarder Hoarder
+
+	// inventoryNames holds the names of the [Inventory] the reference resolves the thing from, followed by the names of the fallback inventories.
+	inventoryNames []string
+
+	// itemName is the custom [Item] name the reference resolves the thing with.
+	itemName string
+
+	// release is a function that stops the reference from following the [Inventory].
+	release func()
+
+	// mu serializes the refreshes so that an older resolution never overwrites a newer one.
+	mu sync.Mutex
+}
+
+// Ref is a function that returns a new [Reference] to the thing of type T in the specified [Inventory].
+// The thing is resolved the same way as the [EquipWithOption] function does, and resolved again whenever the [Inventory] changes.
+// Unlike the [EquipWithOption] function, it does not panic if the thing is not hoarded yet.
+//
+// To specify custom [Item] name or custom [Inventory] name, use the [EquipOptions] when calling the [Ref] function.
+//
+// To specify a custom [Hoarder] to be used, pass the custom [Hoarder] as an argument when calling the [Ref] function.
+//
+// Call the [Reference.Release] method once the reference is no longer needed.
+//
+// Example usage:
+//
+//	ref := Ref[*sql.DB](EquipOptions{}.WithCustomItemName("primary"))
+//	defer ref.Release()
+//
+//	db := ref.Get()
+func Ref[T any](opt EquipOptions, customHoarder ...Hoarder) *Reference[T] {
+	cfg := defaultEquipConfig
+
+	for _, f := range opt {
+		f.apply(&cfg)
+	}
+
+	r := &Reference[T]{
+		hoarder:        pickHoarder(customHoarder...),
+		inventoryNames: cfg.inventoryNames(),
+		itemName:       cfg.customItemName,
+	}
+
+	// only the first lookup is requested by the user, the following ones neither count as equips nor mark the thing as used
+	r.release = observeAcross(r.hoarder, r.inventoryNames, func() {
+		r.refresh(resolveAcross)
+	})
+
+	r.refresh(equipAcross)
+
+	return r
+}
+
+// Get is a method that returns the currently referenced thing.
+// The method returns the zero value of T if the thing is not hoarded.
+// The method costs a single atomic load.
+func (r *Reference[T]) Get() T {
+	if v := r.thing.Load(); v != nil {
+		return *v
+	}
+
+	var zero T
+
+	return zero
+}
+
+// Ok is a method that reports whether the referenced thing is currently hoarded.
+func (r *Reference[T]) Ok() bool {
+	return r.thing.Load() != nil
+}
+
+// Release is a method that stops the reference from following the [Inventory].
+// After calling this method, the [Reference.Get] method keeps returning the last referenced thing.
+func (r *Reference[T]) Release() {
+	r.release()
+}
+
+// refresh is a method that resolves the referenced thing again with the given lookup function.
+// A resolved thing of another type than T, e.g. hoarded under the same alias, is referenced as a thing that is not hoarded.
+func (r *Reference[T]) refresh(lookup func(h Hoarder, typeOfThing reflect.Type, inventoryNames []string, itemName string) (Item, uint64)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	v, _ := lookup(r.hoarder, reflect.TypeFor[T](), r.inventoryNames, r.itemName)
+
+	thing, ok := thingOf[T](v)
+	if !ok {
+		r.thing.Store(nil)
+		return
+	}
+
+	r.thing.Store(&thing)
+}
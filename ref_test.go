@@ -0,0 +1,164 @@
+package hoard
+
+import (
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func (s *suiteTest) TestRef() {
+	tests := []struct {
+		name        string
+		givenOption EquipOptions
+		givenThings [][]interface{}
+		wantOk      bool
+		want        string
+	}{
+		{
+			name:        "should be able to reference a hoarded thing",
+			givenOption: nil,
+			givenThings: [][]interface{}{
+				{"test"},
+			},
+			wantOk: true,
+			want:   "test",
+		},
+		{
+			name:        "should follow the replacements of the referenced thing",
+			givenOption: nil,
+			givenThings: [][]interface{}{
+				{"test"},
+				{"test2"},
+				{"test3"},
+			},
+			wantOk: true,
+			want:   "test3",
+		},
+		{
+			name:        "should follow the replacements of the referenced thing in a custom inventory",
+			givenOption: EquipOptions{}.WithCustomInventoryName("test").WithCustomItemName("test"),
+			givenThings: [][]interface{}{
+				{"test", UseInventory("test").Put(RememberAs("test2", "test"))},
+				{UseInventory("test").Put(RememberAs("test3", "test"))},
+			},
+			wantOk: true,
+			want:   "test3",
+		},
+		{
+			name:        "should return the zero value if the thing is not hoarded",
+			givenOption: EquipOptions{}.WithCustomItemName("test"),
+			givenThings: [][]interface{}{
+				{"test"},
+			},
+			wantOk: false,
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			h := factory()
+
+			ref := Ref[string](tt.givenOption, h)
+			defer ref.Release()
+
+			for _, things := range tt.givenThings {
+				Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), things...)
+			}
+
+			require.Equal(s.T(), tt.wantOk, ref.Ok())
+			require.Equal(s.T(), tt.want, ref.Get())
+		})
+	}
+}
+
+func (s *suiteTest) TestRef_refreshIsNotAnEquip() {
+	recorder := &hooksRecorder{}
+
+	h := factory()
+
+	opt := HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h).WithHooks(recorder).ShouldTrackUsage(true)
+
+	Hoard(opt, "test")
+
+	ref := Ref[string](nil, h)
+	defer ref.Release()
+
+	equips := h.Stats().Equips
+	require.Equal(s.T(), []EquipStats{{Inventory: "default", Type: "string", Equips: 1}}, equips)
+
+	Hoard(opt, "test2")
+
+	require.Equal(s.T(), "test2", ref.Get())
+	require.Equal(s.T(), equips, h.Stats().Equips)
+	require.Len(s.T(), recorder.equips, 1)
+
+	// the thing hoarded after the reference has not been equipped by the user
+	require.Len(s.T(), h.Unused(), 1)
+}
+
+func (s *suiteTest) TestRef_otherTypeUnderAlias() {
+	recorder := &auditRecorder{}
+
+	h := factory()
+
+	ref := Ref[string](EquipOptions{}.WithCustomItemName("x"), h)
+	defer ref.Release()
+
+	require.NotPanics(s.T(), func() {
+		Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h).WithAuditSink(recorder), RememberAs(42, "x"))
+	})
+
+	require.False(s.T(), ref.Ok())
+	require.Equal(s.T(), "", ref.Get())
+
+	// the change is still audited once the reference has been refreshed
+	require.NotEmpty(s.T(), recorder.got())
+}
+
+func (s *suiteTest) TestRef_release() {
+	h := factory("test")
+
+	ref := Ref[string](nil, h)
+	ref.Release()
+
+	Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), "test2")
+
+	require.Equal(s.T(), "test", ref.Get())
+}
+
+func (s *suiteTest) TestRef_concurrent() {
+	h := factory()
+
+	ref := Ref[int](nil, h)
+	defer ref.Release()
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		wg := sync.WaitGroup{}
+		for i := 1; i <= 100; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), i)
+			}()
+			go func() {
+				defer wg.Done()
+				_ = ref.Get()
+			}()
+		}
+		wg.Wait()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		s.FailNow("referencing while hoarding concurrently deadlocked")
+	}
+
+	require.Equal(s.T(), EquipDefault[int](h), ref.Get())
+}
@@ -0,0 +1,72 @@
+package hoard
+
+// ShadowMode is a type that describes whether the things hoarded into a custom [Inventory] are also copied into the default [Inventory],
+// under their type, their alias and their annotated name, so that they can be equipped without specifying the custom [Inventory].
+// To specify the [ShadowMode], use the [WithShadowMode] function for an [Inventory] or the [HoardOptions.WithShadowMode] method for all the inventories of a [Hoard] call.
+type ShadowMode int
+
+const (
+	// shadowUnset is the [ShadowMode] of an [Inventory] that does not specify one, which falls back to the one of the [Hoard] call.
+	shadowUnset ShadowMode = iota
+
+	// ShadowIfAbsent copies the things into the default [Inventory] unless a thing hoarded along with them already holds the slot.
+	// It is the default [ShadowMode], kept for compatibility.
+	ShadowIfAbsent
+
+	// ShadowIsolated never copies the things into the default [Inventory], which can then only be equipped from their own [Inventory].
+	ShadowIsolated
+
+	// ShadowAlways copies the things into the default [Inventory], replacing whatever thing hoarded along with them holds the slot.
+	ShadowAlways
+)
+
+// String is a method that returns the name of the [ShadowMode].
+func (m ShadowMode) String() string {
+	switch m {
+	case ShadowIfAbsent:
+		return "shadow-if-absent"
+	case ShadowIsolated:
+		return "isolated"
+	case ShadowAlways:
+		return "always-export"
+	default:
+		return "unset"
+	}
+}
+
+// or is a method that returns the [ShadowMode], or the given one if it is unset.
+func (m ShadowMode) or(mode ShadowMode) ShadowMode {
+	if m == shadowUnset {
+		return mode
+	}
+
+	return m
+}
+
+// WithShadowMode is a function that returns an [InventoryOption] specifying the [ShadowMode] of the things hoarded into the [Inventory].
+// It takes precedence over the [HoardOptions.WithShadowMode] method.
+// The [ShadowMode] only applies to the things hoarded along with it: the copies already made into the default [Inventory] are left as is.
+//
+// Example usage:
+//
+//	Hoard(nil, UseInventory("payments", WithShadowMode(ShadowIsolated)).Put(RememberAs(paymentsDB, "primary")))
+func WithShadowMode(mode ShadowMode) InventoryOption {
+	return InventoryOption{f: func(cfg *inventoryConfig) *inventoryConfig {
+		cfg.shadowMode = mode
+		return cfg
+	}}
+}
+
+// WithShadowMode is a method that sets the [shadowMode] field in the [hoardConfig] struct to the given value.
+// The method returns a new [HoardOptions] with the updated configuration.
+// Typical usage of this method is to keep every custom [Inventory] of a [Hoard] call isolated from the default [Inventory].
+// An [Inventory] specifying its own [ShadowMode] with the [WithShadowMode] function ignores this option.
+// Example usage:
+//
+//	Hoard(HoardOptions{}.WithShadowMode(ShadowIsolated), things...)
+func (h HoardOptions) WithShadowMode(mode ShadowMode) HoardOptions {
+	return append(h, newFuncHoardOptions(func(opt *hoardConfig) *hoardConfig {
+		opt.shadowMode = mode
+		return opt
+	}))
+}
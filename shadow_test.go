@@ -0,0 +1,97 @@
+package hoard
+
+import (
+	"github.com/stretchr/testify/require"
+)
+
+type testShadowDB struct {
+	Name string
+}
+
+func (s *suiteTest) TestWithShadowMode() {
+	opt := HoardOptions{}.ShouldReplaceGlobal(false)
+
+	hoard := func(opt HoardOptions, mode ShadowMode) Hoarder {
+		return Hoard(opt,
+			&testShadowDB{Name: "root"},
+			UseInventory("payments", WithShadowMode(mode)).
+				Put(RememberAs(&testShadowDB{Name: "payments"}, "primary")).
+				Put(RememberAs(1, "")),
+		)
+	}
+
+	tests := []struct {
+		name string
+		h    Hoarder
+		want []string
+	}{
+		{
+			name: "should shadow the things if absent by default",
+			h:    hoard(opt, shadowUnset),
+			want: []string{"root", "payments", "payments"},
+		},
+		{
+			name: "should shadow the things if absent",
+			h:    hoard(opt, ShadowIfAbsent),
+			want: []string{"root", "payments", "payments"},
+		},
+		{
+			name: "should always shadow the things",
+			h:    hoard(opt, ShadowAlways),
+			want: []string{"payments", "payments", "payments"},
+		},
+		{
+			name: "should never shadow the things if isolated",
+			h:    hoard(opt, ShadowIsolated),
+			want: []string{"root", "", ""},
+		},
+		{
+			name: "should use the shadow mode of the hoard call if the inventory does not specify one",
+			h:    hoard(opt.WithShadowMode(ShadowIsolated), shadowUnset),
+			want: []string{"root", "", ""},
+		},
+		{
+			name: "should use the shadow mode of the inventory over the one of the hoard call",
+			h:    hoard(opt.WithShadowMode(ShadowIsolated), ShadowAlways),
+			want: []string{"payments", "payments", "payments"},
+		},
+	}
+
+	name := func(equip func() *testShadowDB) (name string) {
+		defer func() {
+			if recover() != nil {
+				name = ""
+			}
+		}()
+
+		return equip().Name
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			require.Equal(s.T(), tt.want, []string{
+				name(func() *testShadowDB { return EquipDefault[*testShadowDB](tt.h) }),
+				name(func() *testShadowDB {
+					return EquipWithOption[*testShadowDB](EquipOptions{}.WithCustomItemName("primary"), tt.h)
+				}),
+				name(func() *testShadowDB { return NewKey[*testShadowDB]("", "primary").Equip(tt.h) }),
+			})
+
+			// the things are always equipped from their own inventory
+			require.Equal(s.T(), "payments", EquipWithOption[*testShadowDB](EquipOptions{}.WithCustomInventoryName("payments").WithCustomItemName("primary"), tt.h).Name)
+			require.Equal(s.T(), 1, EquipWithOption[int](EquipOptions{}.WithCustomInventoryName("payments"), tt.h))
+		})
+	}
+
+	// an isolated inventory leaves no slot in the default inventory
+	for _, slot := range Slots(hoard(opt, ShadowIsolated)) {
+		require.Empty(s.T(), slot.ShadowOf)
+	}
+}
+
+func (s *suiteTest) TestShadowMode_String() {
+	require.Equal(s.T(), "shadow-if-absent", ShadowIfAbsent.String())
+	require.Equal(s.T(), "isolated", ShadowIsolated.String())
+	require.Equal(s.T(), "always-export", ShadowAlways.String())
+	require.Equal(s.T(), "unset", ShadowMode(0).String())
+}
@@ -0,0 +1,82 @@
+package hoard
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+)
+
+// Slot is a struct that describes a slot of an [Inventory] of a [Hoarder], without the thing it holds.
+// The struct is returned by the [Slots] function.
+type Slot struct {
+
+	// Inventory is the name of the [Inventory], as given to the [UseInventory] function, or "default" for the default one.
+	Inventory string
+
+	// Key is the name the thing is hoarded under, made of the package path and the name of its type, its custom [Item] name, or both separated by a newline.
+	Key string
+
+	// Type is the type of the thing.
+	Type string
+
+	// Name is the custom [Item] name of the thing, or an empty string if none.
+	Name string
+
+	// ShadowOf is the name of the custom [Inventory] the thing has been copied from, or an empty string if the slot is not a shadow copy in the default [Inventory].
+	ShadowOf string
+}
+
+// Slots is a function that returns every slot of the given [Hoarder], sorted by [Inventory] and key.
+// The global [Hoarder] is used if the given [Hoarder] is nil.
+// Unlike the [DebugHandler], the slots only describe the shape of the [Hoarder], hence they do not change unless what is hoarded where does.
+func Slots(h Hoarder) []Slot {
+	entries := slotEntries(h)
+
+	slots := make([]Slot, 0, len(entries))
+	for _, e := range entries {
+		slots = append(slots, e.slot)
+	}
+
+	return slots
+}
+
+// slotEntry is a struct that holds a [Slot] along with the thing it holds.
+type slotEntry struct {
+	slot  Slot
+	thing interface{}
+}
+
+// slotEntries is a function that returns every slot of the given [Hoarder] along with the thing it holds, sorted by [Inventory] and key.
+func slotEntries(h Hoarder) []slotEntry {
+	entries := make([]slotEntry, 0)
+
+	for k, v := range pickHoarder(h).loadout() {
+		for name, r := range v.records() {
+			if r.item.use() == nil {
+				continue
+			}
+
+			e := slotEntry{
+				slot: Slot{
+					Inventory: getOriginalInventoryName(k),
+					Key:       name,
+				},
+				thing: r.item.use(),
+			}
+
+			e.slot.Type, e.slot.Name = getItemTypeAndName(name, reflect.TypeOf(e.thing))
+
+			if r.shadowOf != "" {
+				e.slot.ShadowOf = getOriginalInventoryName(r.shadowOf)
+			}
+
+			entries = append(entries, e)
+		}
+	}
+
+	slices.SortFunc(entries, func(a, b slotEntry) int {
+		return cmp.Or(cmp.Compare(a.slot.Inventory, b.slot.Inventory), cmp.Compare(a.slot.Key, b.slot.Key))
+	})
+
+	return entries
+}
@@ -0,0 +1,34 @@
+package hoard
+
+import (
+	"github.com/stretchr/testify/require"
+)
+
+func (s *suiteTest) TestSlots() {
+	h := factory()
+
+	Hoard(
+		HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h),
+		42,
+		UseInventory("test").Put(RememberAs(&TestFooImpl{}, "foo")),
+	)
+
+	require.Equal(s.T(), []Slot{
+		{Inventory: "default", Key: "*github.com/oopchi/hoardTestFooImpl", Type: "*hoard.TestFooImpl", ShadowOf: "test"},
+		{Inventory: "default", Key: "*github.com/oopchi/hoardTestFooImpl\nfoo", Type: "*hoard.TestFooImpl", Name: "foo", ShadowOf: "test"},
+		{Inventory: "default", Key: "foo", Type: "*hoard.TestFooImpl", Name: "foo", ShadowOf: "test"},
+		{Inventory: "default", Key: "int", Type: "int"},
+		{Inventory: "test", Key: "*github.com/oopchi/hoardTestFooImpl", Type: "*hoard.TestFooImpl"},
+		{Inventory: "test", Key: "*github.com/oopchi/hoardTestFooImpl\nfoo", Type: "*hoard.TestFooImpl", Name: "foo"},
+		{Inventory: "test", Key: "foo", Type: "*hoard.TestFooImpl", Name: "foo"},
+	}, Slots(h))
+}
+
+func (s *suiteTest) TestSlots_nilThing() {
+	h := Hoard(HoardOptions{}.ShouldReplaceGlobal(false), RememberAs(nil, "x"), 1)
+
+	got := Slots(h)
+
+	require.Equal(s.T(), []Slot{{Inventory: "default", Key: "int", Type: "int"}}, got)
+	require.True(s.T(), Diff(h, factory(1)).Empty())
+}
@@ -0,0 +1,55 @@
+package hoard
+
+import (
+	"errors"
+	"reflect"
+)
+
+var (
+	// ErrReadOnly is returned when changing a read-only [Hoarder], such as the one returned by the [Hoarder.Snapshot] method.
+	ErrReadOnly = errors.New("hoard: read-only hoarder")
+)
+
+// snapshot is a struct that implements a read-only [Hoarder] over a private copy of another [Hoarder].
+// This struct is used internally and should not be used directly.
+// To create a new snapshot, use the [Hoarder.Snapshot] method instead.
+// All methods in this struct are thread-safe.
+type snapshot struct {
+
+	// Hoarder is the private copy of the hoarder the snapshot was taken from, which is never changed.
+	Hoarder
+}
+
+func (h *hoarder) Clone() Hoarder {
+	return h.clone()
+}
+
+func (h *hoarder) Snapshot() Hoarder {
+	return &snapshot{
+		Hoarder: h.clone(),
+	}
+}
+
+func (s *snapshot) merge(hoarder Hoarder) {
+	panic(ErrReadOnly)
+}
+
+func (s *snapshot) discard(typeOfThing reflect.Type, inventoryName, itemName string, o origin) {
+	panic(ErrReadOnly)
+}
+
+func (s *snapshot) apply(operations ...operation) error {
+	return ErrReadOnly
+}
+
+func (s *snapshot) Require(requirements ...Requirement) {
+	panic(ErrReadOnly)
+}
+
+func (s *snapshot) Update(fn func(tx *Tx) error) error {
+	return ErrReadOnly
+}
+
+func (s *snapshot) Snapshot() Hoarder {
+	return s
+}
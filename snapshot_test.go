@@ -0,0 +1,95 @@
+package hoard
+
+import (
+	"reflect"
+
+	"github.com/stretchr/testify/require"
+)
+
+func (s *suiteTest) TestHoarderClone() {
+	foo := &TestFooImpl{Name: "production"}
+	opt := HoardOptions{}.ShouldReplaceGlobal(false)
+
+	h := Hoard(opt, foo, 1, UseInventory("cache").Put(RememberAs("redis", "backend")))
+	h.Require(Need[int](nil))
+
+	c := h.Clone()
+
+	require.True(s.T(), Diff(h, c).Empty())
+	require.Same(s.T(), foo, EquipDefault[*TestFooImpl](c))
+	require.NoError(s.T(), c.Verify())
+
+	// the clone diverges from the hoarder
+	Hoard(opt.WithCustomHoarder(c), 2, UseInventory("cache").Put(RememberAs("memory", "backend")))
+	Discard[*TestFooImpl](nil, c)
+	Discard[int](nil, h)
+
+	require.Equal(s.T(), 2, EquipDefault[int](c))
+	require.Equal(s.T(), "memory", EquipWithOption[string](EquipOptions{}.WithCustomInventoryName("cache").WithCustomItemName("backend"), c))
+	require.Nil(s.T(), h.get(reflect.TypeFor[int](), defaultInventoryName, ""))
+
+	require.Equal(s.T(), "redis", EquipWithOption[string](EquipOptions{}.WithCustomInventoryName("cache").WithCustomItemName("backend"), h))
+	require.Same(s.T(), foo, EquipDefault[*TestFooImpl](h))
+	require.Error(s.T(), h.Verify())
+	require.NoError(s.T(), c.Verify())
+}
+
+func (s *suiteTest) TestHoarderSnapshot() {
+	opt := HoardOptions{}.ShouldReplaceGlobal(false)
+
+	h := Hoard(opt, 1, "test")
+	snap := h.Snapshot()
+
+	Hoard(opt.WithCustomHoarder(h), 2)
+	Discard[string](nil, h)
+
+	// the snapshot keeps the things hoarded when it was taken
+	require.Equal(s.T(), 1, EquipDefault[int](snap))
+	require.Equal(s.T(), "test", EquipDefault[string](snap))
+	require.Equal(s.T(), 2, EquipDefault[int](h))
+
+	require.Same(s.T(), snap, snap.Snapshot())
+
+	tests := []struct {
+		name   string
+		change func()
+	}{
+		{
+			name: "should panic when hoarding into the snapshot",
+			change: func() {
+				Hoard(opt.WithCustomHoarder(snap), 3)
+			},
+		},
+		{
+			name: "should panic when discarding from the snapshot",
+			change: func() {
+				Discard[int](nil, snap)
+			},
+		},
+		{
+			name: "should panic when adding requirements to the snapshot",
+			change: func() {
+				snap.Require(Need[int](nil))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			require.PanicsWithValue(s.T(), ErrReadOnly, tt.change)
+		})
+	}
+
+	require.ErrorIs(s.T(), snap.Update(func(tx *Tx) error { return nil }), ErrReadOnly)
+	require.ErrorIs(s.T(), Rollback[int](nil, 1, snap), ErrReadOnly)
+	require.False(s.T(), CompareAndSwap(nil, 0, 3, snap))
+
+	require.Equal(s.T(), 1, EquipDefault[int](snap))
+
+	// a clone of the snapshot can be changed
+	c := snap.Clone()
+	Hoard(opt.WithCustomHoarder(c), 3)
+
+	require.Equal(s.T(), 3, EquipDefault[int](c))
+	require.Equal(s.T(), 1, EquipDefault[int](snap))
+}
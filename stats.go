@@ -0,0 +1,324 @@
+package hoard
+
+import (
+	"cmp"
+	"expvar"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Stats is a struct that holds a snapshot of the usage statistics of a [Hoarder].
+// The struct is returned by the [Hoarder.Stats] method.
+// Every slice is sorted by inventory and type.
+type Stats struct {
+
+	// Equips holds the lookup statistics of each requested type in each [Inventory].
+	Equips []EquipStats
+
+	// Registrations holds the registration statistics of each hoarded type in each [Inventory].
+	Registrations []RegistrationStats
+
+	// Inventories holds the current statistics of each [Inventory].
+	Inventories []InventoryStats
+}
+
+// EquipStats is a struct that holds the lookup statistics of a requested type in an [Inventory].
+type EquipStats struct {
+
+	// Inventory is the name of the [Inventory], as given to the [UseInventory] function, or "default" for the default one.
+	Inventory string
+
+	// Type is the requested type.
+	Type string
+
+	// Equips is the number of lookups, including the missed ones.
+	Equips uint64
+
+	// Misses is the number of lookups that did not find the thing.
+	Misses uint64
+
+	// InterfaceScans is the number of lookups that found the thing by scanning the [Inventory] for an implementation.
+	InterfaceScans uint64
+}
+
+// RegistrationStats is a struct that holds the registration statistics of a hoarded type in an [Inventory].
+type RegistrationStats struct {
+
+	// Inventory is the name of the [Inventory], as given to the [UseInventory] function, or "default" for the default one.
+	Inventory string
+
+	// Type is the hoarded type.
+	Type string
+
+	// Registrations is the number of times a thing of the type was hoarded into an empty slot.
+	Registrations uint64
+
+	// Replacements is the number of times a thing of the type was hoarded into a slot already holding another thing.
+	Replacements uint64
+}
+
+// InventoryStats is a struct that holds the current statistics of an [Inventory].
+type InventoryStats struct {
+
+	// Inventory is the name of the [Inventory], as given to the [UseInventory] function, or "default" for the default one.
+	Inventory string
+
+	// Items is the number of slots currently holding a thing.
+	// A thing hoarded with a custom [Item] name is held by a slot for each name it can be equipped with.
+	Items int
+}
+
+// statsKey is a struct that identifies the statistics of a type in an [Inventory].
+// The key is built from the internal name of the [Inventory] and the type as is, so that counting a lookup or a change builds no string.
+// This struct is used internally and should not be used directly.
+type statsKey struct {
+	inventory string
+	typ       reflect.Type
+}
+
+// inventoryName is a method that returns the name of the [Inventory] of the key, as given to the [UseInventory] function.
+func (k statsKey) inventoryName() string {
+	return getOriginalInventoryName(k.inventory)
+}
+
+// typeName is a method that returns the name of the type of the key, or "<nil>" for a nil thing.
+func (k statsKey) typeName() string {
+	if k.typ == nil {
+		return "<nil>"
+	}
+
+	return k.typ.String()
+}
+
+// equipCounters is a struct that counts the lookups of a type in an [Inventory].
+// This struct is used internally and should not be used directly.
+type equipCounters struct {
+	equips         atomic.Uint64
+	misses         atomic.Uint64
+	interfaceScans atomic.Uint64
+}
+
+// registrationCounters is a struct that counts the registrations of a type in an [Inventory].
+// This struct is used internally and should not be used directly.
+type registrationCounters struct {
+	registrations atomic.Uint64
+	replacements  atomic.Uint64
+}
+
+// counters is a struct that holds the counters of a [Hoarder].
+// Its zero value is ready to use.
+// This struct is used internally and should not be used directly.
+// All methods in this struct are thread-safe.
+type counters struct {
+	mu sync.RWMutex
+
+	// equips maps a statsKey to its counters.
+	equips map[statsKey]*equipCounters
+
+	// registrations maps a statsKey to its counters.
+	registrations map[statsKey]*registrationCounters
+}
+
+// countEquip is a method that counts a lookup of the given type in the given inventory resolved the given way.
+func (c *counters) countEquip(key statsKey, resolution ResolutionKind) {
+	c.mu.RLock()
+	counter, ok := c.equips[key]
+	c.mu.RUnlock()
+
+	if !ok {
+		c.mu.Lock()
+		if counter, ok = c.equips[key]; !ok {
+			if c.equips == nil {
+				c.equips = make(map[statsKey]*equipCounters)
+			}
+
+			counter = &equipCounters{}
+			c.equips[key] = counter
+		}
+		c.mu.Unlock()
+	}
+
+	counter.equips.Add(1)
+
+	switch resolution {
+	case ResolutionMiss:
+		counter.misses.Add(1)
+	case ResolutionInterfaceScan:
+		counter.interfaceScans.Add(1)
+	}
+}
+
+// countChange is a method that counts the given change if it is a registration or a replacement.
+func (c *counters) countChange(change slotChange) {
+	if change.current.item == nil {
+		return
+	}
+
+	key := statsKey{inventory: change.inventoryName, typ: reflect.TypeOf(change.current.item.use())}
+
+	c.mu.RLock()
+	counter, ok := c.registrations[key]
+	c.mu.RUnlock()
+
+	if !ok {
+		c.mu.Lock()
+		if counter, ok = c.registrations[key]; !ok {
+			if c.registrations == nil {
+				c.registrations = make(map[statsKey]*registrationCounters)
+			}
+
+			counter = &registrationCounters{}
+			c.registrations[key] = counter
+		}
+		c.mu.Unlock()
+	}
+
+	if change.previous.item == nil {
+		counter.registrations.Add(1)
+	} else {
+		counter.replacements.Add(1)
+	}
+}
+
+// snapshot is a method that returns the current value of every counter.
+// The counters of distinct types sharing the same name in an [Inventory] are added up.
+func (c *counters) snapshot() ([]EquipStats, []RegistrationStats) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	equipsByName := make(map[[2]string]EquipStats)
+	for key, counter := range c.equips {
+		name := [2]string{key.inventoryName(), key.typeName()}
+
+		sum := equipsByName[name]
+		sum.Inventory, sum.Type = name[0], name[1]
+		sum.Equips += counter.equips.Load()
+		sum.Misses += counter.misses.Load()
+		sum.InterfaceScans += counter.interfaceScans.Load()
+		equipsByName[name] = sum
+	}
+
+	equips := slices.AppendSeq(make([]EquipStats, 0, len(equipsByName)), maps.Values(equipsByName))
+	slices.SortFunc(equips, func(a, b EquipStats) int {
+		return cmp.Or(cmp.Compare(a.Inventory, b.Inventory), cmp.Compare(a.Type, b.Type))
+	})
+
+	registrationsByName := make(map[[2]string]RegistrationStats)
+	for key, counter := range c.registrations {
+		name := [2]string{key.inventoryName(), key.typeName()}
+
+		sum := registrationsByName[name]
+		sum.Inventory, sum.Type = name[0], name[1]
+		sum.Registrations += counter.registrations.Load()
+		sum.Replacements += counter.replacements.Load()
+		registrationsByName[name] = sum
+	}
+
+	registrations := slices.AppendSeq(make([]RegistrationStats, 0, len(registrationsByName)), maps.Values(registrationsByName))
+	slices.SortFunc(registrations, func(a, b RegistrationStats) int {
+		return cmp.Or(cmp.Compare(a.Inventory, b.Inventory), cmp.Compare(a.Type, b.Type))
+	})
+
+	return equips, registrations
+}
+
+// StatsHandler is a function that returns an [http.Handler] exposing the [Stats] of the global [Hoarder] in the Prometheus text format.
+// The [Stats] are taken from the given custom [Hoarder] instead if any.
+// The exposed metrics are:
+//
+//	hoard_equips_total{inventory, type}           counter of lookups, including the missed ones
+//	hoard_equip_misses_total{inventory, type}     counter of lookups that did not find the thing
+//	hoard_interface_scans_total{inventory, type}  counter of lookups resolved by scanning for an implementation
+//	hoard_registrations_total{inventory, type}    counter of things hoarded into an empty slot
+//	hoard_replacements_total{inventory, type}     counter of things hoarded into a slot already holding another thing
+//	hoard_items{inventory}                        gauge of slots currently holding a thing
+//
+// Example usage:
+//
+//	http.Handle("/metrics", StatsHandler())
+func StatsHandler(customHoarder ...Hoarder) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+		_ = writePrometheus(w, pickHoarder(customHoarder...).Stats())
+	})
+}
+
+// PublishExpvar is a function that publishes the [Stats] of the global [Hoarder] as an [expvar] variable with the given name.
+// The [Stats] are taken from the given custom [Hoarder] instead if any, and are computed each time the variable is read.
+// Like the [expvar.Publish] function, it panics if a variable with the given name is already published.
+//
+// Example usage:
+//
+//	PublishExpvar("hoard")
+func PublishExpvar(name string, customHoarder ...Hoarder) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return pickHoarder(customHoarder...).Stats()
+	}))
+}
+
+// writePrometheus is a function that writes the given [Stats] to the given writer in the Prometheus text format.
+func writePrometheus(w io.Writer, stats Stats) error {
+	b := strings.Builder{}
+
+	writeFamily := func(name, help, typ string, samples func()) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+		samples()
+	}
+
+	writeSample := func(name string, value any, labels ...string) {
+		b.WriteString(name + "{")
+		for i := 0; i < len(labels); i += 2 {
+			if i > 0 {
+				b.WriteString(",")
+			}
+
+			b.WriteString(labels[i] + `="` + escapeLabelValue(labels[i+1]) + `"`)
+		}
+		fmt.Fprintf(&b, "} %v\n", value)
+	}
+
+	equipFamily := func(name, help string, value func(EquipStats) uint64) {
+		writeFamily(name, help, "counter", func() {
+			for _, e := range stats.Equips {
+				writeSample(name, value(e), "inventory", e.Inventory, "type", e.Type)
+			}
+		})
+	}
+
+	registrationFamily := func(name, help string, value func(RegistrationStats) uint64) {
+		writeFamily(name, help, "counter", func() {
+			for _, r := range stats.Registrations {
+				writeSample(name, value(r), "inventory", r.Inventory, "type", r.Type)
+			}
+		})
+	}
+
+	equipFamily("hoard_equips_total", "Number of lookups, including the missed ones.", func(e EquipStats) uint64 { return e.Equips })
+	equipFamily("hoard_equip_misses_total", "Number of lookups that did not find the thing.", func(e EquipStats) uint64 { return e.Misses })
+	equipFamily("hoard_interface_scans_total", "Number of lookups resolved by scanning an inventory for an implementation.", func(e EquipStats) uint64 { return e.InterfaceScans })
+	registrationFamily("hoard_registrations_total", "Number of things hoarded into an empty slot.", func(r RegistrationStats) uint64 { return r.Registrations })
+	registrationFamily("hoard_replacements_total", "Number of things hoarded into a slot already holding another thing.", func(r RegistrationStats) uint64 { return r.Replacements })
+
+	writeFamily("hoard_items", "Number of slots currently holding a thing.", "gauge", func() {
+		for _, i := range stats.Inventories {
+			writeSample("hoard_items", i.Items, "inventory", i.Inventory)
+		}
+	})
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// escapeLabelValue is a function that escapes the given label value as required by the Prometheus text format.
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
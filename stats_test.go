@@ -0,0 +1,122 @@
+package hoard
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func (s *suiteTest) TestStats() {
+	h := factory()
+
+	opt := HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h)
+
+	Hoard(opt, &TestFooImpl{}, UseInventory("test").Put(RememberAs("test", "name")))
+	Hoard(opt, &TestFooImpl{})
+
+	_ = EquipDefault[TestFooer](h)
+
+	Discard[*TestFooImpl](nil, h)
+
+	_ = EquipDefault[string](h)
+	_ = EquipWithOption[string](EquipOptions{}.WithCustomInventoryName("test").WithCustomItemName("name"), h)
+
+	require.Panics(s.T(), func() {
+		_, _ = EquipVersioned[*TestFooImpl](nil, h)
+	})
+
+	got := h.Stats()
+
+	require.Equal(s.T(), []EquipStats{
+		{Inventory: "default", Type: "*hoard.TestFooImpl", Equips: 1, Misses: 1},
+		{Inventory: "default", Type: "hoard.TestFooer", Equips: 1, InterfaceScans: 1},
+		{Inventory: "default", Type: "string", Equips: 1},
+		{Inventory: "test", Type: "string", Equips: 1},
+	}, got.Equips)
+
+	require.Equal(s.T(), []RegistrationStats{
+		{Inventory: "default", Type: "*hoard.TestFooImpl", Registrations: 1, Replacements: 1},
+		{Inventory: "default", Type: "string", Registrations: 3},
+		{Inventory: "test", Type: "string", Registrations: 3},
+	}, got.Registrations)
+
+	require.Equal(s.T(), []InventoryStats{
+		{Inventory: "default", Items: 3},
+		{Inventory: "test", Items: 3},
+	}, got.Inventories)
+}
+
+func (s *suiteTest) TestStats_transaction() {
+	h := factory()
+
+	err := h.Update(func(tx *Tx) error {
+		tx.Put("test")
+
+		_ = EquipDefault[string](tx)
+
+		require.Len(s.T(), tx.Stats().Equips, 1)
+
+		return nil
+	})
+
+	require.NoError(s.T(), err)
+
+	got := h.Stats()
+
+	require.Empty(s.T(), got.Equips)
+	require.Equal(s.T(), []RegistrationStats{{Inventory: "default", Type: "string", Registrations: 1}}, got.Registrations)
+}
+
+func (s *suiteTest) TestStatsHandler() {
+	h := factory()
+
+	Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), "test", UseInventory(`te"st`).Put(RememberAs(1, "")))
+
+	_ = EquipDefault[string](h)
+
+	rec := httptest.NewRecorder()
+
+	StatsHandler(h).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(s.T(), "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	require.Equal(s.T(), `# HELP hoard_equips_total Number of lookups, including the missed ones.
+# TYPE hoard_equips_total counter
+hoard_equips_total{inventory="default",type="string"} 1
+# HELP hoard_equip_misses_total Number of lookups that did not find the thing.
+# TYPE hoard_equip_misses_total counter
+hoard_equip_misses_total{inventory="default",type="string"} 0
+# HELP hoard_interface_scans_total Number of lookups resolved by scanning an inventory for an implementation.
+# TYPE hoard_interface_scans_total counter
+hoard_interface_scans_total{inventory="default",type="string"} 0
+# HELP hoard_registrations_total Number of things hoarded into an empty slot.
+# TYPE hoard_registrations_total counter
+hoard_registrations_total{inventory="default",type="int"} 1
+hoard_registrations_total{inventory="default",type="string"} 1
+hoard_registrations_total{inventory="te\"st",type="int"} 1
+# HELP hoard_replacements_total Number of things hoarded into a slot already holding another thing.
+# TYPE hoard_replacements_total counter
+hoard_replacements_total{inventory="default",type="int"} 0
+hoard_replacements_total{inventory="default",type="string"} 0
+hoard_replacements_total{inventory="te\"st",type="int"} 0
+# HELP hoard_items Number of slots currently holding a thing.
+# TYPE hoard_items gauge
+hoard_items{inventory="default"} 2
+hoard_items{inventory="te\"st"} 1
+`, rec.Body.String())
+}
+
+func (s *suiteTest) TestPublishExpvar() {
+	h := factory()
+
+	Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), "test")
+
+	PublishExpvar("hoard_test_stats", h)
+
+	got := Stats{}
+	require.NoError(s.T(), json.Unmarshal([]byte(expvar.Get("hoard_test_stats").String()), &got))
+
+	require.Equal(s.T(), h.Stats(), got)
+}
@@ -0,0 +1,178 @@
+package hoard
+
+import (
+	"reflect"
+	"sync"
+)
+
+// operationKind is a type that describes what an [operation] does.
+// This type is used internally and should not be used directly.
+type operationKind int
+
+const (
+	// operationMerge merges the given [Hoarder].
+	operationMerge operationKind = iota
+
+	// operationDiscard discards the thing of the given type.
+	operationDiscard
+
+	// operationSwap swaps the thing of the given type with the given thing if its slot has the expected version.
+	operationSwap
+
+	// operationRollback restores the slot of the thing of the given type to the state it had the given number of changes ago.
+	operationRollback
+)
+
+// operation is a struct that holds a single change to be applied to a [Hoarder].
+// This struct is used internally and should not be used directly.
+type operation struct {
+
+	// kind describes what the operation does.
+	kind operationKind
+
+	// hoarder is the [Hoarder] to be merged.
+	hoarder Hoarder
+
+	// typeOfThing is the type of the thing to be changed.
+	typeOfThing reflect.Type
+
+	// inventoryName is the name of the [Inventory] to change the thing in.
+	inventoryName string
+
+	// itemName is the custom [Item] name of the thing to be changed.
+	itemName string
+
+	// expectedVersion is the version the slot of the thing to be swapped must have.
+	expectedVersion uint64
+
+	// thing is the thing to be swapped in.
+	thing interface{}
+
+	// steps is the number of changes to roll back.
+	steps int
+
+	// origin describes the code changing the thing.
+	origin origin
+}
+
+// Tx is a transaction on a [Hoarder], obtained from the [Hoarder.Update] method.
+// A [Tx] is itself a [Hoarder] holding a private copy of the hoarder it was started on:
+// pass it as the custom [Hoarder] to [EquipWithOption], [Discard] or [Hoard] (with global replacement disabled) to read or change that copy.
+// Everything put or discarded through the [Tx] is applied to the original hoarder at once when the transaction succeeds,
+// hence readers of the original hoarder never observe some of the changes without the others.
+// Changes made to the original hoarder by others while the transaction is running are kept, unless overridden by the transaction.
+// If the transaction swapped a thing through [CompareAndSwap] and the thing has been changed by others since, the transaction fails with [ErrVersionMismatch].
+// If the transaction rolled back a thing through [Rollback], the thing is rolled back again from its state at the time the transaction succeeds.
+//
+// A [Tx] must not be used after the function passed to the [Hoarder.Update] method returns.
+// All methods in this struct are thread-safe.
+type Tx struct {
+
+	// Hoarder is the private copy of the hoarder the transaction was started on.
+	Hoarder
+
+	// operations holds the changes to be applied to the original hoarder when the transaction succeeds.
+	operations []operation
+
+	mu sync.Mutex
+}
+
+// Update is a function that runs the given function within a transaction on the global [Hoarder].
+// It is equivalent to calling the [Hoarder.Update] method on the global [Hoarder], or on the given custom [Hoarder] if any.
+//
+// Example usage:
+//
+//	err := Update(func(tx *Tx) error {
+//		tx.Put(newClient, newRepository, newCache)
+//		Discard[*LegacyCache](nil, tx)
+//
+//		return nil
+//	})
+func Update(fn func(tx *Tx) error, customHoarder ...Hoarder) error {
+	return pickHoarder(customHoarder...).Update(fn)
+}
+
+// update is a function that runs the given function within a transaction on the given [Hoarder].
+func update(h Hoarder, fn func(tx *Tx) error) error {
+	tx := &Tx{
+		Hoarder:    h.clone(),
+		operations: make([]operation, 0),
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	return h.apply(tx.operations...)
+}
+
+// Put is a method that hoards the given things within the transaction.
+// The things are handled the same way as the [Hoard] function does, with the default [ShadowMode].
+// To specify another [ShadowMode], use the [Tx.PutWithOption] method.
+// The method returns the same [Tx] to allow chaining.
+//
+// Example usage:
+//
+//	tx.Put(client, RememberAs(repository, "primary"), UseInventory("cache").Put(RememberAs(cache, "")))
+func (tx *Tx) Put(things ...interface{}) *Tx {
+	tx.merge(factoryWithOrigin(newOrigin(0), ShadowIfAbsent, things...))
+
+	return tx
+}
+
+// PutWithOption is a method that hoards the given things within the transaction with the given [HoardOptions].
+// The things are handled the same way as the [Hoard] function does.
+// Only the [ShadowMode] given with the [HoardOptions.WithShadowMode] method is honoured, the other options being ignored:
+// the things are only hoarded into the transaction, and the audit sink, hooks and usage tracking are the ones of the hoarder the transaction was started on.
+// The method returns the same [Tx] to allow chaining.
+//
+// Example usage:
+//
+//	tx.PutWithOption(HoardOptions{}.WithShadowMode(ShadowIsolated), UseInventory("payments").Put(RememberAs(paymentsDB, "primary")))
+func (tx *Tx) PutWithOption(opt HoardOptions, things ...interface{}) *Tx {
+	cfg := defaultHoardConfig
+
+	for _, f := range opt {
+		f.apply(&cfg)
+	}
+
+	tx.merge(factoryWithOrigin(newOrigin(0), cfg.shadowMode, things...))
+
+	return tx
+}
+
+// Update is a method that runs the given function within a nested transaction.
+// Changes of the nested transaction become part of this transaction if the function returns nil,
+// and are discarded if the function returns an error, which is then returned as is.
+func (tx *Tx) Update(fn func(tx *Tx) error) error {
+	return update(tx, fn)
+}
+
+func (tx *Tx) merge(hoarder Hoarder) {
+	if hoarder == nil {
+		return
+	}
+
+	// record a copy so that later changes to the given hoarder are not applied when the transaction succeeds
+	_ = tx.apply(operation{kind: operationMerge, hoarder: hoarder.clone()})
+}
+
+func (tx *Tx) discard(typeOfThing reflect.Type, inventoryName, itemName string, o origin) {
+	_ = tx.apply(operation{kind: operationDiscard, typeOfThing: typeOfThing, inventoryName: inventoryName, itemName: itemName, origin: o})
+}
+
+func (tx *Tx) apply(operations ...operation) error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	if err := tx.Hoarder.apply(operations...); err != nil {
+		return err
+	}
+
+	tx.operations = append(tx.operations, operations...)
+
+	return nil
+}
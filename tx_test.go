@@ -0,0 +1,273 @@
+package hoard
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func (s *suiteTest) TestUpdate() {
+	errTest := errors.New("test error")
+
+	tests := []struct {
+		name       string
+		givenThing []interface{}
+		givenFn    func(tx *Tx) error
+		wantErr    error
+		wantString string
+		wantInt    int
+		wantStruct interface{}
+	}{
+		{
+			name:       "should apply all changes if the function succeeds",
+			givenThing: []interface{}{"test", 1, &TestFooImpl{Name: "test"}},
+			givenFn: func(tx *Tx) error {
+				tx.Put("test2", 2)
+				Discard[*TestFooImpl](nil, tx)
+
+				return nil
+			},
+			wantErr:    nil,
+			wantString: "test2",
+			wantInt:    2,
+			wantStruct: nil,
+		},
+		{
+			name:       "should discard all changes if the function fails",
+			givenThing: []interface{}{"test", 1, &TestFooImpl{Name: "test"}},
+			givenFn: func(tx *Tx) error {
+				tx.Put("test2", 2)
+				Discard[*TestFooImpl](nil, tx)
+
+				return errTest
+			},
+			wantErr:    errTest,
+			wantString: "test",
+			wantInt:    1,
+			wantStruct: &TestFooImpl{Name: "test"},
+		},
+		{
+			name:       "should be able to read the changes within the transaction",
+			givenThing: []interface{}{"test", 1},
+			givenFn: func(tx *Tx) error {
+				tx.Put("test2")
+
+				Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(tx), EquipDefault[string](tx)+"3")
+
+				return nil
+			},
+			wantErr:    nil,
+			wantString: "test23",
+			wantInt:    1,
+			wantStruct: nil,
+		},
+		{
+			name:       "should apply the changes of a nested transaction that succeeds",
+			givenThing: []interface{}{"test", 1},
+			givenFn: func(tx *Tx) error {
+				tx.Put("test2")
+
+				return tx.Update(func(tx *Tx) error {
+					tx.Put(2)
+
+					return nil
+				})
+			},
+			wantErr:    nil,
+			wantString: "test2",
+			wantInt:    2,
+			wantStruct: nil,
+		},
+		{
+			name:       "should discard the changes of a nested transaction that fails",
+			givenThing: []interface{}{"test", 1},
+			givenFn: func(tx *Tx) error {
+				tx.Put("test2")
+
+				_ = tx.Update(func(tx *Tx) error {
+					tx.Put(2)
+
+					return errTest
+				})
+
+				return nil
+			},
+			wantErr:    nil,
+			wantString: "test2",
+			wantInt:    1,
+			wantStruct: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			h := factory(tt.givenThing...)
+
+			err := Update(tt.givenFn, h)
+
+			require.ErrorIs(s.T(), err, tt.wantErr)
+			require.Equal(s.T(), tt.wantString, EquipDefault[string](h))
+			require.Equal(s.T(), tt.wantInt, EquipDefault[int](h))
+			require.Equal(s.T(), tt.wantStruct, h.get(reflect.TypeFor[*TestFooImpl](), defaultInventoryName, ""))
+		})
+	}
+}
+
+func (s *suiteTest) TestUpdate_keepsConcurrentChanges() {
+	h := factory("test", 1)
+
+	err := h.Update(func(tx *Tx) error {
+		Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), 2)
+
+		tx.Put("test2")
+
+		return nil
+	})
+
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "test2", EquipDefault[string](h))
+	require.Equal(s.T(), 2, EquipDefault[int](h))
+}
+
+func (s *suiteTest) TestTx_PutWithOption() {
+	hoard := func(put func(tx *Tx)) Hoarder {
+		h := factory(&testShadowDB{Name: "root"})
+
+		require.NoError(s.T(), h.Update(func(tx *Tx) error {
+			put(tx)
+
+			return nil
+		}))
+
+		return h
+	}
+
+	payments := func() interface{} {
+		return UseInventory("payments").Put(RememberAs(&testShadowDB{Name: "payments"}, "primary"))
+	}
+
+	s.Run("should shadow the things if absent with Put", func() {
+		h := hoard(func(tx *Tx) { tx.Put(payments()) })
+
+		require.Equal(s.T(), "payments", NewKey[*testShadowDB]("", "primary").Equip(h).Name)
+	})
+
+	s.Run("should use the shadow mode of the options with PutWithOption", func() {
+		h := hoard(func(tx *Tx) { tx.PutWithOption(HoardOptions{}.WithShadowMode(ShadowIsolated), payments()) })
+
+		require.Panics(s.T(), func() { NewKey[*testShadowDB]("", "primary").Equip(h) })
+		require.Equal(s.T(), "root", EquipDefault[*testShadowDB](h).Name)
+		require.Equal(s.T(), "payments", EquipWithOption[*testShadowDB](EquipOptions{}.WithCustomInventoryName("payments").WithCustomItemName("primary"), h).Name)
+	})
+
+	s.Run("should always shadow the things with PutWithOption", func() {
+		h := hoard(func(tx *Tx) { tx.PutWithOption(HoardOptions{}.WithShadowMode(ShadowAlways), payments()) })
+
+		require.Equal(s.T(), "payments", EquipDefault[*testShadowDB](h).Name)
+	})
+}
+
+func (s *suiteTest) TestUpdate_atomic() {
+	h := factory("0", 0, UseInventory("test").Put(RememberAs("0", "")))
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		wg := sync.WaitGroup{}
+		for i := 1; i <= 100; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+
+				_ = h.Update(func(tx *Tx) error {
+					tx.Put(strconv.Itoa(i), i, UseInventory("test").Put(RememberAs(strconv.Itoa(i), "")))
+
+					return nil
+				})
+			}()
+			go func() {
+				defer wg.Done()
+
+				// a clone is a consistent view of the hoarder at a single point in time
+				c := h.clone()
+
+				require.Equal(s.T(), strconv.Itoa(EquipDefault[int](c)), EquipDefault[string](c))
+				require.Equal(s.T(), EquipDefault[string](c), EquipWithOption[string](EquipOptions{}.WithCustomInventoryName("test"), c))
+			}()
+		}
+		wg.Wait()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		s.FailNow("updating concurrently deadlocked")
+	}
+}
+
+func (s *suiteTest) TestDiscard() {
+	tests := []struct {
+		name        string
+		givenThings []interface{}
+		givenOption EquipOptions
+		wantString  interface{}
+		wantNamed   interface{}
+	}{
+		{
+			name:        "should be able to discard a thing from the default inventory",
+			givenThings: []interface{}{"test", RememberAs("test2", "test")},
+			givenOption: nil,
+			wantString:  nil,
+			wantNamed:   "test2",
+		},
+		{
+			name:        "should be able to discard a named thing and its alias",
+			givenThings: []interface{}{"test", RememberAs("test2", "test")},
+			givenOption: EquipOptions{}.WithCustomItemName("test"),
+			wantString:  "test",
+			wantNamed:   nil,
+		},
+		{
+			name:        "should not discard an alias holding a thing of another type",
+			givenThings: []interface{}{"test", RememberAs(1, "test")},
+			givenOption: EquipOptions{}.WithCustomItemName("test"),
+			wantString:  "test",
+			wantNamed:   1,
+		},
+		{
+			name:        "should do nothing if the inventory is not mapped",
+			givenThings: []interface{}{"test"},
+			givenOption: EquipOptions{}.WithCustomInventoryName("test"),
+			wantString:  "test",
+			wantNamed:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			h := factory(tt.givenThings...)
+
+			Discard[string](tt.givenOption, h)
+
+			require.Equal(s.T(), tt.wantString, h.get(reflect.TypeFor[string](), defaultInventoryName, ""))
+			require.Equal(s.T(), tt.wantNamed, h.get(reflect.TypeFor[string](), defaultInventoryName, "test"))
+		})
+	}
+}
+
+func (s *suiteTest) TestDiscard_notifies() {
+	h := factory("test")
+
+	ref := Ref[string](nil, h)
+	defer ref.Release()
+
+	Discard[string](nil, h)
+
+	require.False(s.T(), ref.Ok())
+}
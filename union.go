@@ -0,0 +1,303 @@
+package hoard
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// union is a struct that implements a read-only [Hoarder] resolving things across several other [Hoarder].
+// This struct is used internally and should not be used directly.
+// To create a new union, use the [Union] function instead.
+// All methods in this struct are thread-safe.
+type union struct {
+
+	// members holds the hoarders things are resolved from, by decreasing priority.
+	members []Hoarder
+
+	mu sync.RWMutex
+
+	// requirements holds the requirements verified by the Verify method.
+	requirements []Requirement
+}
+
+// Union is a function that returns a [Hoarder] resolving things across the given [Hoarder], in the given order of precedence.
+// A nil [Hoarder] stands for the global one.
+//
+// A thing is resolved from each [Hoarder] in turn, the same way as the [EquipWithOption] function does,
+// hence its exact name, its alias and the implementations of an interface are all looked up in a [Hoarder] before moving on to the next one.
+// The lookup is reported to the [Hooks], the statistics and the usage tracking of the [Hoarder] the thing is found in,
+// or of the first [Hoarder] if none holds it.
+//
+// Nothing is copied: each [Hoarder] can still be changed on its own, and the changes are visible through the union straight away,
+// including to the [Watch], [Ref] and [EquipWait] functions.
+// The union itself is read-only: passing it to the [Hoard] or [Discard] functions panics with [ErrReadOnly],
+// while the [CompareAndSwap], [Rollback] and [Update] functions fail with [ErrReadOnly].
+// Requirements added to the union with its Require method are verified against the union.
+//
+// Inspecting the union, e.g. with the [Slots], [Diff] or [Graph] functions, describes a copy of its slots,
+// the slot of a [Hoarder] hiding the slot of the same name of the ones after it.
+// The Clone and Snapshot methods return such a copy too.
+//
+// Example usage:
+//
+//	app := Union([]Hoarder{overrides, payments.Hoarder(), orders.Hoarder()})
+//
+//	client := EquipDefault[*http.Client](app)
+func Union(precedence []Hoarder) Hoarder {
+	members := make([]Hoarder, 0, len(precedence))
+	for _, h := range precedence {
+		members = append(members, pickHoarder(h))
+	}
+
+	return &union{
+		members:      members,
+		requirements: make([]Requirement, 0),
+	}
+}
+
+func (u *union) get(typeOfThing reflect.Type, inventoryName, itemName string) interface{} {
+	if v, _ := u.equip(typeOfThing, inventoryName, itemName); v != nil {
+		return v.use()
+	}
+
+	return nil
+}
+
+func (u *union) resolve(typeOfThing reflect.Type, inventoryName, itemName string) (Item, uint64) {
+	for _, m := range u.members {
+		if v, version := m.resolve(typeOfThing, inventoryName, itemName); v != nil {
+			return v, version
+		}
+	}
+
+	return nil, 0
+}
+
+func (u *union) equip(typeOfThing reflect.Type, inventoryName, itemName string) (Item, uint64) {
+	for _, m := range u.members {
+		if v, _ := m.resolve(typeOfThing, inventoryName, itemName); v == nil {
+			continue
+		}
+
+		// the member may have changed since the thing was resolved
+		if v, version := m.equip(typeOfThing, inventoryName, itemName); v != nil {
+			return v, version
+		}
+	}
+
+	if len(u.members) == 0 {
+		return nil, 0
+	}
+
+	return u.members[0].equip(typeOfThing, inventoryName, itemName)
+}
+
+func (u *union) equipExact(typeOfThing reflect.Type, inventoryName, itemName, thingName string) (Item, uint64) {
+	for _, m := range u.members {
+		if !holdsExact(m, inventoryName, thingName) {
+			continue
+		}
+
+		// the member may have changed since the thing was resolved
+		if v, version := m.equipExact(typeOfThing, inventoryName, itemName, thingName); v != nil {
+			return v, version
+		}
+	}
+
+	if len(u.members) == 0 {
+		return nil, 0
+	}
+
+	return u.members[0].equipExact(typeOfThing, inventoryName, itemName, thingName)
+}
+
+// holdsExact is a function that reports whether the given [Hoarder] holds a thing under the given exact name in the specified inventory.
+func holdsExact(h Hoarder, inventoryName, thingName string) bool {
+	for k, v := range h.loadout() {
+		if k == inventoryName {
+			return v.equip(thingName) != nil
+		}
+	}
+
+	return false
+}
+
+func (u *union) loadout() func(func(string, Inventory) bool) {
+	return u.clone().loadout()
+}
+
+func (u *union) merge(hoarder Hoarder) {
+	panic(ErrReadOnly)
+}
+
+func (u *union) observe(inventoryName string, notify func()) func() {
+	cancels := make([]func(), 0, len(u.members))
+	for _, m := range u.members {
+		cancels = append(cancels, m.observe(inventoryName, notify))
+	}
+
+	return func() {
+		for _, cancel := range cancels {
+			cancel()
+		}
+	}
+}
+
+func (u *union) discard(typeOfThing reflect.Type, inventoryName, itemName string, o origin) {
+	panic(ErrReadOnly)
+}
+
+func (u *union) recall(typeOfThing reflect.Type, inventoryName, itemName string) []record {
+	for _, m := range u.members {
+		if v, _ := m.resolve(typeOfThing, inventoryName, itemName); v != nil {
+			return m.recall(typeOfThing, inventoryName, itemName)
+		}
+	}
+
+	if len(u.members) == 0 {
+		return nil
+	}
+
+	return u.members[0].recall(typeOfThing, inventoryName, itemName)
+}
+
+func (u *union) apply(operations ...operation) error {
+	return ErrReadOnly
+}
+
+// setAuditSink is a method that does nothing, since the changes are made to the members of the union.
+func (u *union) setAuditSink(auditSink AuditSink) {}
+
+// setHooks is a method that does nothing, since the lookups are reported to the members of the union.
+func (u *union) setHooks(hooks Hooks) {}
+
+// setTrackUsage is a method that does nothing, since the lookups are reported to the members of the union.
+func (u *union) setTrackUsage(trackUsage bool) {}
+
+// clone is a method that returns a copy of the slots of the union, the slot of a member hiding the slot of the same name of the members after it.
+func (u *union) clone() Hoarder {
+	h := factory()
+
+	for _, m := range slices.Backward(u.members) {
+		h.merge(m)
+	}
+
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	h.Require(u.requirements...)
+
+	return h
+}
+
+// Stats is a method that returns the statistics of the members of the union added up,
+// along with the current statistics of each [Inventory] of the copy of its slots.
+func (u *union) Stats() Stats {
+	equips := make(map[[2]string]EquipStats)
+	registrations := make(map[[2]string]RegistrationStats)
+
+	for _, m := range u.members {
+		stats := m.Stats()
+
+		for _, e := range stats.Equips {
+			k := [2]string{e.Inventory, e.Type}
+
+			sum := equips[k]
+			sum.Inventory, sum.Type = e.Inventory, e.Type
+			sum.Equips += e.Equips
+			sum.Misses += e.Misses
+			sum.InterfaceScans += e.InterfaceScans
+			equips[k] = sum
+		}
+
+		for _, r := range stats.Registrations {
+			k := [2]string{r.Inventory, r.Type}
+
+			sum := registrations[k]
+			sum.Inventory, sum.Type = r.Inventory, r.Type
+			sum.Registrations += r.Registrations
+			sum.Replacements += r.Replacements
+			registrations[k] = sum
+		}
+	}
+
+	stats := Stats{
+		Equips:        make([]EquipStats, 0, len(equips)),
+		Registrations: make([]RegistrationStats, 0, len(registrations)),
+		Inventories:   u.clone().Stats().Inventories,
+	}
+
+	for _, e := range equips {
+		stats.Equips = append(stats.Equips, e)
+	}
+
+	slices.SortFunc(stats.Equips, func(a, b EquipStats) int {
+		return cmp.Or(cmp.Compare(a.Inventory, b.Inventory), cmp.Compare(a.Type, b.Type))
+	})
+
+	for _, r := range registrations {
+		stats.Registrations = append(stats.Registrations, r)
+	}
+
+	slices.SortFunc(stats.Registrations, func(a, b RegistrationStats) int {
+		return cmp.Or(cmp.Compare(a.Inventory, b.Inventory), cmp.Compare(a.Type, b.Type))
+	})
+
+	return stats
+}
+
+// Unused is a method that returns the things of every member of the union that have not been equipped since usage tracking was enabled on that member.
+// The method returns nil if usage tracking is disabled on every member.
+func (u *union) Unused() []UnusedItem {
+	var items []UnusedItem
+
+	for _, m := range u.members {
+		unused := m.Unused()
+		if unused == nil {
+			continue
+		}
+
+		if items == nil {
+			items = make([]UnusedItem, 0, len(unused))
+		}
+
+		items = append(items, unused...)
+	}
+
+	slices.SortFunc(items, func(a, b UnusedItem) int {
+		return cmp.Or(cmp.Compare(a.Inventory, b.Inventory), cmp.Compare(a.Type, b.Type), cmp.Compare(a.Name, b.Name))
+	})
+
+	return items
+}
+
+func (u *union) Require(requirements ...Requirement) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.requirements = append(u.requirements, requirements...)
+}
+
+func (u *union) Verify() error {
+	u.mu.RLock()
+	requirements := slices.Clone(u.requirements)
+	u.mu.RUnlock()
+
+	return verifyRequirements(u, requirements)
+}
+
+func (u *union) Update(fn func(tx *Tx) error) error {
+	return ErrReadOnly
+}
+
+func (u *union) Clone() Hoarder {
+	return u.clone()
+}
+
+func (u *union) Snapshot() Hoarder {
+	return &snapshot{
+		Hoarder: u.clone(),
+	}
+}
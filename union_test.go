@@ -0,0 +1,226 @@
+package hoard
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testUnionService struct {
+	Name string
+}
+
+func (s *testUnionService) name() string {
+	return s.Name
+}
+
+type testUnionClient struct {
+	Name string
+}
+
+type testUnionNamer interface {
+	name() string
+}
+
+func (s *suiteTest) TestUnion() {
+	opt := HoardOptions{}.ShouldReplaceGlobal(false)
+
+	overrides := Hoard(opt, &testUnionService{Name: "override"})
+	payments := Hoard(opt,
+		&testUnionService{Name: "payments"},
+		RememberAs(&testUnionClient{Name: "payments"}, "bar"),
+		UseInventory("eu").Put(RememberAs(1, "port")),
+	)
+	orders := Hoard(opt,
+		RememberAs(&testUnionClient{Name: "orders"}, "bar"),
+		"orders",
+		UseInventory("eu").Put(RememberAs(2, "port")),
+	)
+
+	u := Union([]Hoarder{overrides, payments, orders})
+
+	tests := []struct {
+		name  string
+		equip func() interface{}
+		want  interface{}
+	}{
+		{
+			name: "should resolve from the first hoarder holding the thing",
+			equip: func() interface{} {
+				return EquipDefault[*testUnionService](u).Name
+			},
+			want: "override",
+		},
+		{
+			name: "should resolve the item name from the first hoarder holding it",
+			equip: func() interface{} {
+				return EquipWithOption[*testUnionClient](EquipOptions{}.WithCustomItemName("bar"), u).Name
+			},
+			want: "payments",
+		},
+		{
+			name: "should resolve from a custom inventory",
+			equip: func() interface{} {
+				return EquipWithOption[int](EquipOptions{}.WithCustomInventoryName("eu").WithCustomItemName("port"), u)
+			},
+			want: 1,
+		},
+		{
+			name: "should resolve from the last hoarder",
+			equip: func() interface{} {
+				return EquipDefault[string](u)
+			},
+			want: "orders",
+		},
+		{
+			name: "should resolve an interface from the first hoarder holding an implementation",
+			equip: func() interface{} {
+				return EquipDefault[testUnionNamer](u).name()
+			},
+			want: "override",
+		},
+		{
+			name: "should resolve an exact key from the first hoarder holding it",
+			equip: func() interface{} {
+				return NewKey[*testUnionClient]("", "bar").Equip(u).Name
+			},
+			want: "payments",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			require.Equal(s.T(), tt.want, tt.equip())
+		})
+	}
+
+	require.Panics(s.T(), func() {
+		EquipDefault[float64](u)
+	})
+
+	// each hoarder is still changed on its own
+	Discard[*testUnionService](nil, overrides)
+	require.Equal(s.T(), "payments", EquipDefault[*testUnionService](u).Name)
+
+	Hoard(opt.WithCustomHoarder(orders), 3.14)
+	require.Equal(s.T(), 3.14, EquipDefault[float64](u))
+
+	// the slots of a hoarder hide the ones of the hoarders after it
+	require.Equal(s.T(), []Slot{
+		{Inventory: "default", Key: "*github.com/oopchi/hoardtestUnionClient", Type: "*hoard.testUnionClient"},
+		{Inventory: "default", Key: "*github.com/oopchi/hoardtestUnionClient\nbar", Type: "*hoard.testUnionClient", Name: "bar"},
+		{Inventory: "default", Key: "*github.com/oopchi/hoardtestUnionService", Type: "*hoard.testUnionService"},
+		{Inventory: "default", Key: "bar", Type: "*hoard.testUnionClient", Name: "bar"},
+		{Inventory: "default", Key: "float64", Type: "float64"},
+		{Inventory: "default", Key: "int", Type: "int", ShadowOf: "eu"},
+		{Inventory: "default", Key: "int\nport", Type: "int", Name: "port", ShadowOf: "eu"},
+		{Inventory: "default", Key: "port", Type: "int", Name: "port", ShadowOf: "eu"},
+		{Inventory: "default", Key: "string", Type: "string"},
+		{Inventory: "eu", Key: "int", Type: "int"},
+		{Inventory: "eu", Key: "int\nport", Type: "int", Name: "port"},
+		{Inventory: "eu", Key: "port", Type: "int", Name: "port"},
+	}, Slots(u))
+
+	require.Equal(s.T(), "payments", EquipWithOption[*testUnionClient](EquipOptions{}.WithCustomItemName("bar"), u.Clone()).Name)
+	require.Equal(s.T(), 1, EquipWithOption[int](EquipOptions{}.WithCustomItemName("port"), u.Snapshot()))
+}
+
+func (s *suiteTest) TestUnion_readOnly() {
+	opt := HoardOptions{}.ShouldReplaceGlobal(false)
+
+	h := Hoard(opt, 1)
+	u := Union([]Hoarder{h})
+
+	require.PanicsWithValue(s.T(), ErrReadOnly, func() {
+		Hoard(opt.WithCustomHoarder(u), 2)
+	})
+
+	require.PanicsWithValue(s.T(), ErrReadOnly, func() {
+		Discard[int](nil, u)
+	})
+
+	require.ErrorIs(s.T(), u.Update(func(tx *Tx) error { return nil }), ErrReadOnly)
+	require.ErrorIs(s.T(), Rollback[int](nil, 1, u), ErrReadOnly)
+	require.False(s.T(), CompareAndSwap(nil, 0, 2, u))
+
+	require.Equal(s.T(), 1, EquipDefault[int](u))
+}
+
+func (s *suiteTest) TestUnion_verify() {
+	opt := HoardOptions{}.ShouldReplaceGlobal(false)
+
+	a := Hoard(opt, 1)
+	b := Hoard(opt, "test")
+	u := Union([]Hoarder{a, b})
+
+	u.Require(Need[int](nil), Need[string](nil))
+	require.NoError(s.T(), u.Verify())
+
+	u.Require(Need[float64](nil))
+	require.ErrorIs(s.T(), u.Verify(), ErrRequirementNotMet)
+
+	// the requirements of the union are not added to its members
+	require.NoError(s.T(), a.Verify())
+}
+
+func (s *suiteTest) TestUnion_stats() {
+	opt := HoardOptions{}.ShouldReplaceGlobal(false).ShouldTrackUsage(true)
+
+	a := Hoard(opt, 1)
+	b := Hoard(opt, 2, "test")
+	u := Union([]Hoarder{a, b})
+
+	EquipDefault[int](u)
+	EquipDefault[string](u)
+	_ = u.get(reflect.TypeFor[float64](), defaultInventoryName, "")
+
+	// a lookup is reported to the hoarder the thing is found in, or to the first one
+	require.Equal(s.T(), []EquipStats{
+		{Inventory: "default", Type: "float64", Equips: 1, Misses: 1},
+		{Inventory: "default", Type: "int", Equips: 1},
+	}, a.Stats().Equips)
+
+	require.Equal(s.T(), []EquipStats{
+		{Inventory: "default", Type: "string", Equips: 1},
+	}, b.Stats().Equips)
+
+	require.Equal(s.T(), []EquipStats{
+		{Inventory: "default", Type: "float64", Equips: 1, Misses: 1},
+		{Inventory: "default", Type: "int", Equips: 1},
+		{Inventory: "default", Type: "string", Equips: 1},
+	}, u.Stats().Equips)
+
+	require.Equal(s.T(), []InventoryStats{
+		{Inventory: "default", Items: 2},
+	}, u.Stats().Inventories)
+
+	unused := u.Unused()
+	require.Len(s.T(), unused, 1)
+	require.Equal(s.T(), "int", unused[0].Type)
+}
+
+func (s *suiteTest) TestUnion_watch() {
+	opt := HoardOptions{}.ShouldReplaceGlobal(false)
+
+	a := Hoard(opt)
+	b := Hoard(opt)
+	u := Union([]Hoarder{a, b})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	done := make(chan int)
+	go func() {
+		v, err := EquipWait[int](ctx, nil, u)
+		s.NoError(err)
+
+		done <- v
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	Hoard(opt.WithCustomHoarder(b), 42)
+
+	require.Equal(s.T(), 42, <-done)
+}
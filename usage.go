@@ -0,0 +1,177 @@
+package hoard
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UnusedItem is a struct that describes a thing hoarded but never equipped.
+// The struct is returned by the [Hoarder.Unused] method.
+type UnusedItem struct {
+
+	// Inventory is the name of the [Inventory] the thing was hoarded into, as given to the [UseInventory] function, or "default" for the default one.
+	Inventory string
+
+	// Type is the type of the thing.
+	Type string
+
+	// Name is the custom [Item] name of the thing, or an empty string if none.
+	Name string
+
+	// Caller is the file and line, formatted as "file:line", of the code that hoarded the thing.
+	Caller string
+
+	// At is the time the thing was hoarded.
+	At time.Time
+}
+
+func (h *hoarder) Unused() []UnusedItem {
+	h.mu.RLock()
+	trackUsage := h.trackUsage
+	h.mu.RUnlock()
+
+	if !trackUsage {
+		return nil
+	}
+
+	// group the slots of each thing, which share the same hoarding
+	unused := make(map[uint64]*UnusedItem)
+	for k, v := range h.loadout() {
+		for name, r := range v.records() {
+			if r.item.use() == nil {
+				continue
+			}
+
+			if _, ok := h.equipped.Load(r.hoarding); ok {
+				continue
+			}
+
+			typ, alias := getItemTypeAndName(name, reflect.TypeOf(r.item.use()))
+
+			u, ok := unused[r.hoarding]
+			if !ok {
+				u = &UnusedItem{
+					Inventory: getOriginalInventoryName(k),
+					Type:      typ,
+					Caller:    r.caller(),
+					At:        r.at,
+				}
+				unused[r.hoarding] = u
+			}
+
+			if r.shadowOf != "" {
+				u.Inventory = getOriginalInventoryName(r.shadowOf)
+			}
+
+			if alias != "" {
+				u.Name = alias
+			}
+		}
+	}
+
+	items := make([]UnusedItem, 0, len(unused))
+	for _, u := range unused {
+		items = append(items, *u)
+	}
+
+	slices.SortFunc(items, func(a, b UnusedItem) int {
+		return cmp.Or(cmp.Compare(a.Inventory, b.Inventory), cmp.Compare(a.Type, b.Type), cmp.Compare(a.Name, b.Name))
+	})
+
+	return items
+}
+
+// UsageReport is a struct that describes the things that seem useless to a [Hoarder].
+// The struct is returned by the [ReportUsage] function.
+type UsageReport struct {
+
+	// Unused holds the things hoarded but never equipped, as returned by the [Hoarder.Unused] method.
+	Unused []UnusedItem
+
+	// AlwaysMissed holds the lookup statistics of the requested types that have never been found.
+	AlwaysMissed []EquipStats
+}
+
+// ReportUsage is a function that returns the [UsageReport] of the global [Hoarder], typically when the application shuts down.
+// The [UsageReport] of the given custom [Hoarder] is returned instead if any.
+// The things hoarded but never equipped are only reported if usage tracking is enabled, refer to the [HoardOptions.ShouldTrackUsage] method.
+//
+// Example usage:
+//
+//	Hoard(HoardOptions{}.ShouldTrackUsage(true), things...)
+//	defer func() {
+//		log.Print(ReportUsage())
+//	}()
+func ReportUsage(customHoarder ...Hoarder) UsageReport {
+	h := pickHoarder(customHoarder...)
+
+	report := UsageReport{
+		Unused:       h.Unused(),
+		AlwaysMissed: make([]EquipStats, 0),
+	}
+
+	for _, e := range h.Stats().Equips {
+		if e.Equips > 0 && e.Misses == e.Equips {
+			report.AlwaysMissed = append(report.AlwaysMissed, e)
+		}
+	}
+
+	return report
+}
+
+// String is a method that returns a human-readable description of the [UsageReport].
+// Example output:
+//
+//	hoard: 1 thing hoarded but never equipped:
+//		*legacy.Cache in inventory default, hoarded by bootstrap/init.go:42
+//	hoard: 1 type always missed:
+//		*db.Replica in inventory default, missed 3 times
+func (r UsageReport) String() string {
+	b := strings.Builder{}
+
+	b.WriteString("hoard: " + plural(len(r.Unused), "thing") + " hoarded but never equipped")
+	for i, u := range r.Unused {
+		if i == 0 {
+			b.WriteString(":")
+		}
+
+		b.WriteString("\n\t" + describeItem(u.Type, u.Name, u.Inventory))
+
+		if u.Caller != "" {
+			b.WriteString(", hoarded by " + shortCaller(u.Caller))
+		}
+	}
+
+	b.WriteString("\nhoard: " + plural(len(r.AlwaysMissed), "type") + " always missed")
+	for i, e := range r.AlwaysMissed {
+		if i == 0 {
+			b.WriteString(":")
+		}
+
+		b.WriteString("\n\t" + describeItem(e.Type, "", e.Inventory) + ", missed " + plural(int(e.Misses), "time"))
+	}
+
+	return b.String()
+}
+
+// describeItem is a function that returns a human-readable description of a thing of the given type and custom [Item] name in the given [Inventory].
+func describeItem(typ, name, inventory string) string {
+	if name != "" {
+		typ += " named " + strconv.Quote(name)
+	}
+
+	return typ + " in inventory " + inventory
+}
+
+// plural is a function that returns the given count followed by the given noun, pluralized if necessary.
+func plural(count int, noun string) string {
+	if count != 1 {
+		noun += "s"
+	}
+
+	return strconv.Itoa(count) + " " + noun
+}
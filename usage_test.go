@@ -0,0 +1,131 @@
+package hoard
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/stretchr/testify/require"
+)
+
+func (s *suiteTest) TestUnused() {
+	tests := []struct {
+		name       string
+		givenTrack bool
+		givenEquip func(h Hoarder)
+		want       []string
+	}{
+		{
+			name:       "should report every thing if nothing has been equipped",
+			givenTrack: true,
+			givenEquip: func(h Hoarder) {},
+			want:       []string{"default int ", "default string answer", "test *hoard.TestFooImpl foo"},
+		},
+		{
+			name:       "should not report a thing equipped under any of its names",
+			givenTrack: true,
+			givenEquip: func(h Hoarder) {
+				_ = EquipWithOption[string](EquipOptions{}.WithCustomItemName("answer"), h)
+			},
+			want: []string{"default int ", "test *hoard.TestFooImpl foo"},
+		},
+		{
+			name:       "should not report a thing equipped from the default inventory it is shadowed into",
+			givenTrack: true,
+			givenEquip: func(h Hoarder) {
+				_ = EquipDefault[TestFooer](h)
+				_ = EquipDefault[int](h)
+			},
+			want: []string{"default string answer"},
+		},
+		{
+			name:       "should report nothing if usage is not tracked",
+			givenTrack: false,
+			givenEquip: func(h Hoarder) {},
+			want:       nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			h := factory()
+
+			Hoard(
+				HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h).ShouldTrackUsage(tt.givenTrack),
+				1,
+				RememberAs("test", "answer"),
+				UseInventory("test").Put(RememberAs(&TestFooImpl{}, "foo")),
+			)
+
+			tt.givenEquip(h)
+
+			got := h.Unused()
+
+			var gotItems []string
+			for _, u := range got {
+				gotItems = append(gotItems, u.Inventory+" "+u.Type+" "+u.Name)
+
+				require.True(s.T(), strings.Contains(u.Caller, "usage_test.go:"), u.Caller)
+			}
+
+			require.Equal(s.T(), tt.want, gotItems)
+		})
+	}
+}
+
+func (s *suiteTest) TestUnused_hoardedAgain() {
+	h := factory()
+
+	opt := HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h).ShouldTrackUsage(true)
+
+	Hoard(opt, "test")
+
+	_ = EquipDefault[string](h)
+
+	require.Empty(s.T(), h.Unused())
+
+	Hoard(opt, "test2")
+
+	require.Len(s.T(), h.Unused(), 1)
+}
+
+func (s *suiteTest) TestReportUsage() {
+	h := factory()
+
+	Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h).ShouldTrackUsage(true), "test", 1)
+
+	_ = EquipDefault[string](h)
+	_ = h.get(reflect.TypeFor[string](), getCustomInventoryName("missing"), "")
+	_ = h.get(reflect.TypeFor[string](), getCustomInventoryName("missing"), "")
+	_ = h.get(reflect.TypeFor[TestFooer](), defaultInventoryName, "")
+
+	got := ReportUsage(h)
+
+	require.Len(s.T(), got.Unused, 1)
+	require.Equal(s.T(), "int", got.Unused[0].Type)
+
+	require.Equal(s.T(), []EquipStats{
+		{Inventory: "default", Type: "hoard.TestFooer", Equips: 1, Misses: 1},
+		{Inventory: "missing", Type: "string", Equips: 2, Misses: 2},
+	}, got.AlwaysMissed)
+
+	lines := strings.Split(got.String(), "\n")
+
+	require.Len(s.T(), lines, 5)
+	require.Equal(s.T(), "hoard: 1 thing hoarded but never equipped:", lines[0])
+	require.True(s.T(), strings.HasPrefix(lines[1], "\tint in inventory default, hoarded by "), lines[1])
+	require.True(s.T(), strings.Contains(lines[1], "/usage_test.go:"), lines[1])
+	require.Equal(s.T(), "hoard: 2 types always missed:", lines[2])
+	require.Equal(s.T(), "\thoard.TestFooer in inventory default, missed 1 time", lines[3])
+	require.Equal(s.T(), "\tstring in inventory missing, missed 2 times", lines[4])
+
+	require.Equal(s.T(), "hoard: 0 things hoarded but never equipped\nhoard: 0 types always missed", UsageReport{}.String())
+}
+
+func (s *suiteTest) TestUnused_nilThing() {
+	h := Hoard(HoardOptions{}.ShouldReplaceGlobal(false).ShouldTrackUsage(true), RememberAs(nil, "x"), 1)
+
+	got := h.Unused()
+
+	require.Len(s.T(), got, 1)
+	require.Equal(s.T(), "int", got[0].Type)
+}
@@ -0,0 +1,164 @@
+package hoard
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+var (
+	// ErrRequirementNotMet is returned, joined with one error per unmet [Requirement], by the [Hoarder.Verify] method.
+	ErrRequirementNotMet = errors.New("hoard: requirement not met")
+)
+
+// Requirement is a struct that describes a thing needed by the application, to be verified by the [Hoarder.Verify] method.
+// To create a [Requirement], use the [Need] function.
+type Requirement struct {
+
+	// typeOfThing is the type of the needed thing.
+	typeOfThing reflect.Type
+
+	// inventoryName is the name of the [Inventory] the needed thing is equipped from.
+	inventoryName string
+
+	// itemName is the custom [Item] name the needed thing is equipped with.
+	itemName string
+
+	// fallbackInventoryNames holds the names of the inventories the needed thing is equipped from if not found in the [Inventory].
+	fallbackInventoryNames []string
+
+	// selector is the selector the labels of the needed thing must match, refer to the [EquipOptions.WithSelector] method.
+	selector string
+
+	// optional is a boolean that reports whether the application works without the needed thing.
+	optional bool
+}
+
+// Need is a function that returns a [Requirement] for the thing of type T in the specified [Inventory].
+// The [Requirement] is met if the thing can be equipped the same way as with the [EquipWithOption] function.
+//
+// To specify custom [Item] name or custom [Inventory] name, use the [EquipOptions] when calling the [Need] function.
+//
+// Example usage:
+//
+//	h.Require(
+//		Need[*sql.DB](EquipOptions{}.WithCustomItemName("primary")),
+//		Need[*redis.Client](nil).Optional(),
+//	)
+func Need[T any](opt EquipOptions) Requirement {
+	cfg := defaultEquipConfig
+
+	for _, f := range opt {
+		f.apply(&cfg)
+	}
+
+	inventoryNames := cfg.inventoryNames()
+
+	return Requirement{
+		typeOfThing:            reflect.TypeFor[T](),
+		inventoryName:          inventoryNames[0],
+		itemName:               cfg.customItemName,
+		fallbackInventoryNames: inventoryNames[1:],
+		selector:               cfg.selector,
+	}
+}
+
+// Optional is a method that returns a copy of the [Requirement] that is met even if the thing cannot be equipped.
+// An optional [Requirement] documents a thing the application can do without, and never makes the [Hoarder.Verify] method fail.
+func (r Requirement) Optional() Requirement {
+	r.optional = true
+
+	return r
+}
+
+// IsOptional is a method that reports whether the [Requirement] is optional.
+func (r Requirement) IsOptional() bool {
+	return r.optional
+}
+
+// String is a method that returns a human-readable description of the [Requirement].
+// Example output:
+//
+//	*sql.DB named "primary" in inventory default
+func (r Requirement) String() string {
+	if r.typeOfThing == nil {
+		return "<nil> in inventory " + getOriginalInventoryName(r.inventoryName)
+	}
+
+	description := describeItem(r.typeOfThing.String(), r.itemName, getOriginalInventoryName(r.inventoryName))
+	if r.selector != "" {
+		description += " with selector " + strconv.Quote(r.selector)
+	}
+
+	return description
+}
+
+// Require is a function that adds the given requirements to the global [Hoarder].
+// It is equivalent to calling the [Hoarder.Require] method on the global [Hoarder].
+func Require(requirements ...Requirement) {
+	globalFactory().Require(requirements...)
+}
+
+// Verify is a function that verifies the requirements of the global [Hoarder].
+// It is equivalent to calling the [Hoarder.Verify] method on the global [Hoarder], or on the given custom [Hoarder] if any.
+//
+// Example usage:
+//
+//	Require(Need[*sql.DB](nil), Need[*Config](nil))
+//	if err := Verify(); err != nil {
+//		log.Fatal(err)
+//	}
+func Verify(customHoarder ...Hoarder) error {
+	return pickHoarder(customHoarder...).Verify()
+}
+
+func (h *hoarder) Require(requirements ...Requirement) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.requirements = append(h.requirements, requirements...)
+}
+
+func (h *hoarder) Verify() error {
+	h.mu.RLock()
+	requirements := slices.Clone(h.requirements)
+	h.mu.RUnlock()
+
+	return verifyRequirements(h, requirements)
+}
+
+// verifyRequirements is a function that checks the given requirements against the given [Hoarder] at once,
+// the same way as the [Hoarder.Verify] method does.
+func verifyRequirements(h Hoarder, requirements []Requirement) error {
+	errs := make([]error, 0)
+	for _, r := range requirements {
+		if r.optional {
+			continue
+		}
+
+		// exactly one thing must match the selector
+		if r.typeOfThing != nil && r.selector != "" {
+			_, err := selectAcross(h, r.typeOfThing, append([]string{r.inventoryName}, r.fallbackInventoryNames...), r.itemName, r.selector)
+			switch {
+			case err == nil:
+				continue
+			case !errors.Is(err, ErrNoMatch):
+				errs = append(errs, fmt.Errorf("%w: %w", ErrRequirementNotMet, err))
+				continue
+			}
+		}
+
+		// a zero Requirement, not created by the Need function, is never met
+		if r.typeOfThing != nil && r.selector == "" {
+			if v, _ := resolveAcross(h, r.typeOfThing, append([]string{r.inventoryName}, r.fallbackInventoryNames...), r.itemName); v != nil {
+				continue
+			}
+		}
+
+		errs = append(errs, fmt.Errorf("%w: %s is not hoarded", ErrRequirementNotMet, r))
+	}
+
+	return errors.Join(errs...)
+}
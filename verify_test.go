@@ -0,0 +1,112 @@
+package hoard
+
+import (
+	"errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+func (s *suiteTest) TestVerify() {
+	tests := []struct {
+		name             string
+		givenRequirement []Requirement
+		wantErrs         []string
+	}{
+		{
+			name:             "should succeed without any requirement",
+			givenRequirement: nil,
+			wantErrs:         nil,
+		},
+		{
+			name: "should succeed if every required thing is hoarded",
+			givenRequirement: []Requirement{
+				Need[string](nil),
+				Need[int](EquipOptions{}.WithCustomItemName("answer")),
+				Need[TestFooer](EquipOptions{}.WithCustomInventoryName("test")),
+			},
+			wantErrs: nil,
+		},
+		{
+			name: "should report every missing thing at once",
+			givenRequirement: []Requirement{
+				Need[string](nil),
+				Need[bool](nil),
+				Need[int](EquipOptions{}.WithCustomItemName("question")),
+				Need[string](EquipOptions{}.WithCustomInventoryName("test")),
+			},
+			wantErrs: []string{
+				"hoard: requirement not met: bool in inventory default is not hoarded",
+				`hoard: requirement not met: int named "question" in inventory default is not hoarded`,
+				"hoard: requirement not met: string in inventory test is not hoarded",
+			},
+		},
+		{
+			name: "should ignore a missing optional thing",
+			givenRequirement: []Requirement{
+				Need[bool](nil).Optional(),
+			},
+			wantErrs: nil,
+		},
+		{
+			name: "should never meet a zero requirement",
+			givenRequirement: []Requirement{
+				{},
+			},
+			wantErrs: []string{
+				"hoard: requirement not met: <nil> in inventory  is not hoarded",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			h := factory()
+
+			Hoard(
+				HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h),
+				"test",
+				RememberAs(42, "answer"),
+				UseInventory("test").Put(RememberAs(&TestFooImpl{}, "")),
+			)
+
+			h.Require(tt.givenRequirement...)
+
+			err := Verify(h)
+
+			if tt.wantErrs == nil {
+				require.NoError(s.T(), err)
+				return
+			}
+
+			require.ErrorIs(s.T(), err, ErrRequirementNotMet)
+
+			var gotErrs []string
+			for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+				gotErrs = append(gotErrs, e.Error())
+			}
+
+			require.Equal(s.T(), tt.wantErrs, gotErrs)
+		})
+	}
+}
+
+func (s *suiteTest) TestVerify_afterWiring() {
+	h := factory()
+
+	h.Require(Need[string](nil))
+
+	require.True(s.T(), errors.Is(h.Verify(), ErrRequirementNotMet))
+
+	Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), "test")
+
+	require.NoError(s.T(), h.Verify())
+}
+
+func (s *suiteTest) TestRequirement() {
+	got := Need[*TestFooImpl](EquipOptions{}.WithCustomInventoryName("test").WithCustomItemName("foo"))
+
+	require.False(s.T(), got.IsOptional())
+	require.True(s.T(), got.Optional().IsOptional())
+	require.False(s.T(), got.IsOptional())
+	require.Equal(s.T(), `*hoard.TestFooImpl named "foo" in inventory test`, got.String())
+}
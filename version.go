@@ -0,0 +1,96 @@
+package hoard
+
+import (
+	"errors"
+	"reflect"
+)
+
+var (
+	// ErrVersionMismatch is returned when the version of a slot does not match the expected version.
+	ErrVersionMismatch = errors.New("hoard: version mismatch")
+)
+
+// EquipVersioned is a function that returns the requested thing from the specified [Inventory], along with the version of its slot.
+// The thing is resolved the same way as the [EquipWithOption] function does.
+// The version of a slot starts at 0 and is increased each time a thing is hoarded into or discarded from the slot, hence it never goes back.
+// The function returns the requested thing if found. Otherwise, it panics.
+//
+// Pass the returned version to the [CompareAndSwap] function to replace the thing only if nobody else did in the meantime.
+//
+// To specify custom [Item] name or custom [Inventory] name, use the [EquipOptions] when calling the [EquipVersioned] function.
+//
+// To specify a custom [Hoarder] to be used, pass the custom [Hoarder] as an argument when calling the [EquipVersioned] function.
+//
+// The [EquipVersioned] function is thread-safe.
+//
+// Example usage:
+//
+//	client, version := EquipVersioned[*oauth.Client](nil)
+func EquipVersioned[T any](opt EquipOptions, customHoarder ...Hoarder) (T, uint64) {
+	cfg := defaultEquipConfig
+
+	for _, f := range opt {
+		f.apply(&cfg)
+	}
+
+	typeOfType := reflect.TypeFor[T]()
+
+	hoarder := pickHoarder(customHoarder...)
+
+	v, version := equipAcross(hoarder, typeOfType, cfg.inventoryNames(), cfg.customItemName)
+
+	var thing interface{}
+
+	if v != nil {
+		thing = v.use()
+	}
+
+	return thing.(T), version
+}
+
+// CompareAndSwap is a function that replaces the requested thing in the specified [Inventory] with the given thing,
+// only if the version of its slot is still the expected version.
+// The replaced slot is the one the thing is resolved from the same way as the [EquipWithOption] function does,
+// or the slot of the exact [Item] name if the thing is not hoarded, whose version is 0 unless a thing has been discarded from it.
+// The other slots the replaced thing is hoarded under, i.e. its type name, alias and exact [Item] name along with its copies shadowed into the default [Inventory],
+// are replaced as well, so that the given thing is equipped whichever way the replaced one was.
+// The function reports whether the thing has been replaced.
+//
+// Unlike the [Hoard] function, the given thing is only put into the specified [Inventory].
+// Swapping in a nil thing discards the thing from its slot.
+//
+// To specify custom [Item] name or custom [Inventory] name, use the [EquipOptions] when calling the [CompareAndSwap] function.
+//
+// To specify a custom [Hoarder] to be used, pass the custom [Hoarder] as an argument when calling the [CompareAndSwap] function.
+// Passing a [Tx] replaces the thing only once the transaction succeeds, and fails the transaction if the thing has been changed by others since.
+//
+// The [CompareAndSwap] function is thread-safe.
+//
+// Example usage:
+//
+//	client, version := EquipVersioned[*oauth.Client](nil)
+//
+//	if !CompareAndSwap(nil, version, client.Rotate()) {
+//		// another goroutine rotated the client first
+//	}
+func CompareAndSwap[T any](opt EquipOptions, expectedVersion uint64, newThing T, customHoarder ...Hoarder) bool {
+	cfg := defaultEquipConfig
+
+	for _, f := range opt {
+		f.apply(&cfg)
+	}
+
+	hoarder := pickHoarder(customHoarder...)
+
+	err := hoarder.apply(operation{
+		kind:            operationSwap,
+		typeOfThing:     reflect.TypeFor[T](),
+		inventoryName:   getCustomInventoryName(cfg.customInventoryName),
+		itemName:        cfg.customItemName,
+		expectedVersion: expectedVersion,
+		thing:           newThing,
+		origin:          newOrigin(0),
+	})
+
+	return err == nil
+}
@@ -0,0 +1,228 @@
+package hoard
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/stretchr/testify/require"
+)
+
+func (s *suiteTest) TestEquipVersioned() {
+	tests := []struct {
+		name        string
+		givenHoards [][]interface{}
+		givenOption EquipOptions
+		want        string
+		wantVersion uint64
+	}{
+		{
+			name:        "should return the version of a thing hoarded once",
+			givenHoards: [][]interface{}{{"test"}},
+			givenOption: nil,
+			want:        "test",
+			wantVersion: 1,
+		},
+		{
+			name:        "should increase the version each time the thing is hoarded",
+			givenHoards: [][]interface{}{{"test"}, {"test2"}, {"test3"}},
+			givenOption: nil,
+			want:        "test3",
+			wantVersion: 3,
+		},
+		{
+			name: "should return the version of the slot in a custom inventory",
+			givenHoards: [][]interface{}{
+				{UseInventory("test").Put(RememberAs("test", "test"))},
+				{UseInventory("test").Put(RememberAs("test2", "test"))},
+			},
+			givenOption: EquipOptions{}.WithCustomInventoryName("test").WithCustomItemName("test"),
+			want:        "test2",
+			wantVersion: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			h := factory()
+
+			for _, things := range tt.givenHoards {
+				Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), things...)
+			}
+
+			got, gotVersion := EquipVersioned[string](tt.givenOption, h)
+
+			require.Equal(s.T(), tt.want, got)
+			require.Equal(s.T(), tt.wantVersion, gotVersion)
+		})
+	}
+}
+
+func (s *suiteTest) TestEquipVersioned_panic() {
+	require.Panics(s.T(), func() {
+		EquipVersioned[string](nil, factory())
+	})
+}
+
+func (s *suiteTest) TestCompareAndSwap() {
+	tests := []struct {
+		name                 string
+		givenThings          []interface{}
+		givenOption          EquipOptions
+		givenExpectedVersion uint64
+		givenThing           TestFooer
+		wantSwapped          bool
+		want                 interface{}
+		wantVersion          uint64
+	}{
+		{
+			name:                 "should swap the thing if the version matches",
+			givenThings:          []interface{}{TestFooImpl{Name: "test"}},
+			givenOption:          nil,
+			givenExpectedVersion: 1,
+			givenThing:           TestFooImpl{Name: "test2"},
+			wantSwapped:          true,
+			want:                 TestFooImpl{Name: "test2"},
+			wantVersion:          2,
+		},
+		{
+			name:                 "should not swap the thing if the version does not match",
+			givenThings:          []interface{}{TestFooImpl{Name: "test"}},
+			givenOption:          nil,
+			givenExpectedVersion: 0,
+			givenThing:           TestFooImpl{Name: "test2"},
+			wantSwapped:          false,
+			want:                 TestFooImpl{Name: "test"},
+			wantVersion:          1,
+		},
+		{
+			name:                 "should put the thing if it is not hoarded and the expected version is 0",
+			givenThings:          nil,
+			givenOption:          EquipOptions{}.WithCustomInventoryName("test").WithCustomItemName("test"),
+			givenExpectedVersion: 0,
+			givenThing:           TestFooImpl{Name: "test2"},
+			wantSwapped:          true,
+			want:                 TestFooImpl{Name: "test2"},
+			wantVersion:          1,
+		},
+		{
+			name:                 "should discard the thing if the given thing is nil",
+			givenThings:          []interface{}{TestFooImpl{Name: "test"}},
+			givenOption:          nil,
+			givenExpectedVersion: 1,
+			givenThing:           nil,
+			wantSwapped:          true,
+			want:                 nil,
+			wantVersion:          0,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			h := factory(tt.givenThings...)
+
+			got := CompareAndSwap(tt.givenOption, tt.givenExpectedVersion, tt.givenThing, h)
+
+			require.Equal(s.T(), tt.wantSwapped, got)
+
+			cfg := defaultEquipConfig
+			for _, f := range tt.givenOption {
+				f.apply(&cfg)
+			}
+
+			gotItem, gotVersion := h.resolve(reflect.TypeFor[TestFooer](), getCustomInventoryName(cfg.customInventoryName), cfg.customItemName)
+
+			if tt.want == nil {
+				require.Nil(s.T(), gotItem)
+				return
+			}
+
+			require.Equal(s.T(), tt.want, gotItem.use())
+			require.Equal(s.T(), tt.wantVersion, gotVersion)
+		})
+	}
+}
+
+func (s *suiteTest) TestCompareAndSwap_siblingSlots() {
+	h := factory(
+		RememberAs("test", "primary"),
+		UseInventory("payments").Put(RememberAs(42, "db")),
+	)
+
+	s.Run("should swap every slot of a named thing", func() {
+		opt := EquipOptions{}.WithCustomItemName("primary")
+
+		_, version := EquipVersioned[string](opt, h)
+		require.True(s.T(), CompareAndSwap(opt, version, "test2", h))
+
+		require.Equal(s.T(), "test2", EquipWithOption[string](opt, h))
+		require.Equal(s.T(), "test2", EquipDefault[string](h))
+
+		// the type name, alias and exact name slots all hold the new thing
+		for name, r := range h.(*hoarder).inventoryMap[defaultInventoryName].records() {
+			if _, ok := r.item.use().(string); ok {
+				require.Equal(s.T(), "test2", r.item.use(), name)
+			}
+		}
+	})
+
+	s.Run("should swap the copies shadowed into the default inventory", func() {
+		opt := EquipOptions{}.WithCustomInventoryName("payments").WithCustomItemName("db")
+
+		_, version := EquipVersioned[int](opt, h)
+		require.True(s.T(), CompareAndSwap(opt, version, 43, h))
+
+		require.Equal(s.T(), 43, EquipWithOption[int](opt, h))
+		require.Equal(s.T(), 43, EquipDefault[int](h))
+		require.Equal(s.T(), 43, EquipWithOption[int](EquipOptions{}.WithCustomItemName("db"), h))
+		require.Equal(s.T(), 43, EquipWithOption[int](EquipOptions{}.WithCustomInventoryName("payments"), h))
+	})
+}
+
+func (s *suiteTest) TestCompareAndSwap_concurrent() {
+	h := factory(0)
+
+	swapped := atomic.Int64{}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				v, version := EquipVersioned[int](nil, h)
+
+				if CompareAndSwap(nil, version, v+1, h) {
+					swapped.Add(1)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(s.T(), int64(100), swapped.Load())
+	require.Equal(s.T(), 100, EquipDefault[int](h))
+}
+
+func (s *suiteTest) TestCompareAndSwap_transaction() {
+	h := factory("test", 1)
+
+	_, version := EquipVersioned[string](nil, h)
+
+	err := h.Update(func(tx *Tx) error {
+		tx.Put(2)
+
+		require.True(s.T(), CompareAndSwap(nil, version, "test2", tx))
+
+		// another goroutine swaps the same thing before the transaction succeeds
+		require.True(s.T(), CompareAndSwap(nil, version, "test3", h))
+
+		return nil
+	})
+
+	require.ErrorIs(s.T(), err, ErrVersionMismatch)
+	require.Equal(s.T(), "test3", EquipDefault[string](h))
+	require.Equal(s.T(), 1, EquipDefault[int](h))
+}
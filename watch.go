@@ -0,0 +1,168 @@
+package hoard
+
+import (
+	"context"
+	"reflect"
+)
+
+// ChangeKind is a type that describes what happened to a watched thing.
+type ChangeKind int
+
+const (
+	// ChangeHoarded means the watched thing was hoarded while it was not hoarded before.
+	ChangeHoarded ChangeKind = iota + 1
+
+	// ChangeReplaced means the watched thing was replaced by another one, e.g. through [Inventory.Put] or by hoarding it again.
+	ChangeReplaced
+
+	// ChangeRemoved means the watched thing is not hoarded anymore.
+	ChangeRemoved
+)
+
+// String is a method that returns the name of the [ChangeKind].
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeHoarded:
+		return "hoarded"
+	case ChangeReplaced:
+		return "replaced"
+	case ChangeRemoved:
+		return "removed"
+	}
+
+	return "unknown"
+}
+
+// Change is a struct that describes a change of a watched thing.
+// The struct is delivered by the channel returned from the [Watch] function.
+type Change[T any] struct {
+
+	// Kind describes what happened to the watched thing.
+	Kind ChangeKind
+
+	// Old is the thing before the change, or the zero value of T if the thing was not hoarded.
+	Old T
+
+	// New is the thing after the change, or the zero value of T if the thing is not hoarded anymore.
+	New T
+}
+
+// Watch is a function that returns a channel delivering a [Change] whenever the thing of type T in the specified [Inventory] changes.
+// The thing is resolved the same way as the [EquipWithOption] function does, and a [Change] is delivered whenever it is hoarded, replaced or removed.
+//
+// Changes happening while the previous [Change] has not been received yet are coalesced,
+// hence the [Change.Old] of the next delivered [Change] is always the [Change.New] of the previously delivered one.
+//
+// The channel is closed once the given context is done.
+//
+// To specify custom [Item] name or custom [Inventory] name, use the [EquipOptions] when calling the [Watch] function.
+//
+// To specify a custom [Hoarder] to be used, pass the custom [Hoarder] as an argument when calling the [Watch] function.
+//
+// Example usage:
+//
+//	for change := range Watch[*Config](ctx, nil) {
+//		pool.Reconfigure(change.New)
+//	}
+func Watch[T any](ctx context.Context, opt EquipOptions, customHoarder ...Hoarder) <-chan Change[T] {
+	cfg := defaultEquipConfig
+
+	for _, f := range opt {
+		f.apply(&cfg)
+	}
+
+	typeOfType := reflect.TypeFor[T]()
+
+	hoarder := pickHoarder(customHoarder...)
+
+	inventoryNames := cfg.inventoryNames()
+
+	changed := make(chan struct{}, 1)
+
+	release := observeAcross(hoarder, inventoryNames, func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	})
+
+	last := resolveTypedAcross[T](hoarder, typeOfType, inventoryNames, cfg.customItemName)
+
+	changes := make(chan Change[T])
+
+	go func() {
+		defer close(changes)
+		defer release()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-changed:
+			}
+
+			current := resolveTypedAcross[T](hoarder, typeOfType, inventoryNames, cfg.customItemName)
+
+			if current == last {
+				continue
+			}
+
+			change := newChange[T](last, current)
+			last = current
+
+			select {
+			case <-ctx.Done():
+				return
+			case changes <- change:
+			}
+		}
+	}()
+
+	return changes
+}
+
+// resolveTypedAcross is a function that resolves the requested thing the same way as the resolveAcross function does,
+// and returns nil if the resolved [Item] does not hold a thing of type T, e.g. if a thing of another type is hoarded under the same alias.
+func resolveTypedAcross[T any](h Hoarder, typeOfThing reflect.Type, inventoryNames []string, itemName string) Item {
+	v, _ := resolveAcross(h, typeOfThing, inventoryNames, itemName)
+
+	if _, ok := thingOf[T](v); !ok {
+		return nil
+	}
+
+	return v
+}
+
+// thingOf is a function that returns the thing held by the given [Item] and true if it is of type T,
+// or the zero value of T and false otherwise, including if the given [Item] is nil.
+func thingOf[T any](v Item) (T, bool) {
+	if v == nil {
+		var zero T
+
+		return zero, false
+	}
+
+	thing, ok := v.use().(T)
+
+	return thing, ok
+}
+
+// newChange is a function that returns a new [Change] describing the change from the old [Item] to the new [Item].
+// An [Item] not holding a thing of type T is described as no [Item].
+func newChange[T any](old, new Item) Change[T] {
+	change := Change[T]{
+		Kind: ChangeReplaced,
+	}
+
+	var ok bool
+
+	if change.Old, ok = thingOf[T](old); !ok {
+		change.Kind = ChangeHoarded
+	}
+
+	if change.New, ok = thingOf[T](new); !ok {
+		change.Kind = ChangeRemoved
+	}
+
+	return change
+}
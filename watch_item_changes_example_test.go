@@ -0,0 +1,29 @@
+package hoard_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oopchi/hoard"
+)
+
+type WClient struct {
+	Name string
+}
+
+func ExampleWatch() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	customHoarder := hoard.Hoard(hoard.HoardOptions{}.ShouldReplaceGlobal(false), &WClient{Name: "client v1"})
+
+	changes := hoard.Watch[*WClient](ctx, nil, customHoarder)
+
+	// Swap the client at runtime
+	hoard.Hoard(hoard.HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(customHoarder), &WClient{Name: "client v2"})
+
+	change := <-changes
+
+	fmt.Println(change.Kind, change.Old.Name, "->", change.New.Name)
+	// Output: replaced client v1 -> client v2
+}
@@ -0,0 +1,173 @@
+package hoard
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func (s *suiteTest) TestWatch() {
+	tests := []struct {
+		name        string
+		givenOption EquipOptions
+		givenHoard  []interface{}
+		wantChanges []Change[string]
+	}{
+		{
+			name:        "should deliver a change when the thing is hoarded and replaced",
+			givenOption: nil,
+			givenHoard:  []interface{}{"test", "test2", "test2"},
+			wantChanges: []Change[string]{
+				{Kind: ChangeHoarded, Old: "", New: "test"},
+				{Kind: ChangeReplaced, Old: "test", New: "test2"},
+				{Kind: ChangeReplaced, Old: "test2", New: "test2"},
+			},
+		},
+		{
+			name:        "should deliver a change when the thing is hoarded in a custom inventory",
+			givenOption: EquipOptions{}.WithCustomInventoryName("test").WithCustomItemName("test"),
+			givenHoard: []interface{}{
+				UseInventory("test").Put(RememberAs("test", "test")),
+				UseInventory("test").Put(RememberAs("test2", "test")),
+			},
+			wantChanges: []Change[string]{
+				{Kind: ChangeHoarded, Old: "", New: "test"},
+				{Kind: ChangeReplaced, Old: "test", New: "test2"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			h := factory()
+
+			changes := Watch[string](ctx, tt.givenOption, h)
+
+			for i, thing := range tt.givenHoard {
+				Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), thing)
+
+				select {
+				case got := <-changes:
+					require.Equal(s.T(), tt.wantChanges[i], got)
+				case <-time.After(5 * time.Second):
+					s.FailNow("change was not delivered")
+				}
+			}
+		})
+	}
+}
+
+func (s *suiteTest) TestWatch_unrelatedChange() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := factory()
+
+	changes := Watch[string](ctx, nil, h)
+
+	Hoard(HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h), 42)
+
+	select {
+	case got := <-changes:
+		s.FailNow("unexpected change delivered", "%v", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func (s *suiteTest) TestWatch_otherTypeUnderAlias() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := factory()
+	opt := HoardOptions{}.ShouldReplaceGlobal(false).WithCustomHoarder(h)
+
+	changes := Watch[string](ctx, EquipOptions{}.WithCustomItemName("y"), h)
+
+	require.NotPanics(s.T(), func() {
+		Hoard(opt, RememberAs(42, "y"))
+	})
+
+	select {
+	case got := <-changes:
+		s.FailNow("unexpected change delivered", "%v", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	Hoard(opt, RememberAs("test", "y"))
+
+	select {
+	case got := <-changes:
+		require.Equal(s.T(), Change[string]{Kind: ChangeHoarded, New: "test"}, got)
+	case <-time.After(5 * time.Second):
+		s.FailNow("change was not delivered")
+	}
+}
+
+func (s *suiteTest) TestWatch_contextDone() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	h := factory()
+
+	changes := Watch[string](ctx, nil, h)
+
+	cancel()
+
+	select {
+	case _, ok := <-changes:
+		require.False(s.T(), ok)
+	case <-time.After(5 * time.Second):
+		s.FailNow("channel was not closed")
+	}
+
+	h.(*hoarder).observersMu.Lock()
+	defer h.(*hoarder).observersMu.Unlock()
+
+	require.Empty(s.T(), h.(*hoarder).observers)
+}
+
+func (s *suiteTest) Test_newChange() {
+	tests := []struct {
+		name     string
+		givenOld Item
+		givenNew Item
+		want     Change[string]
+	}{
+		{
+			name:     "should describe a hoarded thing",
+			givenOld: nil,
+			givenNew: newItem("test", "string"),
+			want:     Change[string]{Kind: ChangeHoarded, New: "test"},
+		},
+		{
+			name:     "should describe a replaced thing",
+			givenOld: newItem("test", "string"),
+			givenNew: newItem("test2", "string"),
+			want:     Change[string]{Kind: ChangeReplaced, Old: "test", New: "test2"},
+		},
+		{
+			name:     "should describe a removed thing",
+			givenOld: newItem("test", "string"),
+			givenNew: nil,
+			want:     Change[string]{Kind: ChangeRemoved, Old: "test"},
+		},
+		{
+			name:     "should describe a thing of another type as a removed thing",
+			givenOld: newItem("test", "string"),
+			givenNew: newItem(42, "y"),
+			want:     Change[string]{Kind: ChangeRemoved, Old: "test"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			got := newChange[string](tt.givenOld, tt.givenNew)
+
+			require.Equal(s.T(), tt.want, got)
+			require.Equal(s.T(), tt.want.Kind.String(), got.Kind.String())
+		})
+	}
+}